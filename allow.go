@@ -0,0 +1,88 @@
+package ensure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AllowOnly adds an error with the code "unknown_field" to each attribute of the record that is not one of fields. This
+// rejects unexpected keys such as misspelled or unsupported JSON fields instead of silently ignoring them. The record
+// must implement KeysGetterSetter, as GetterSetterMap does, or AllowOnly panics.
+func (r *RecordWithErrors) AllowOnly(fields ...string) {
+	keys, ok := recordKeys(r.record)
+	if !ok {
+		panic(fmt.Errorf("%T does not implement KeysGetterSetter", r.record))
+	}
+
+	allowed := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		allowed[field] = struct{}{}
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := allowed[key]; !ok {
+			r.Add(key, NewError("unknown_field", "is not an allowed field", nil))
+		}
+	}
+}
+
+// Permit deletes each attribute of the record that is not one of fields. Like strong parameters in Rails, this prevents
+// mass assignment of attributes that should not be set from input, such as "admin", when the record is later applied
+// to a model. The record must implement DeleteGetterSetter, as GetterSetterMap does, or Permit panics.
+func (r *RecordWithErrors) Permit(fields ...string) {
+	record, ok := unwrapRecord(r.record).(DeleteGetterSetter)
+	if !ok {
+		panic(fmt.Errorf("%T does not implement DeleteGetterSetter", r.record))
+	}
+
+	allowed := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		allowed[field] = struct{}{}
+	}
+
+	for _, key := range record.Keys() {
+		if _, ok := allowed[key]; !ok {
+			record.Delete(key)
+		}
+	}
+}
+
+// EnsurePrefix ensures each attribute of the record whose name starts with prefix with the same ensurers. It is
+// intended for open-ended sets of fields such as custom "X-" headers with Headers. e.g.
+//
+//	r.EnsurePrefix("X-", ensure.SingleLineString(), ensure.MaxLen(256))
+//
+// Attributes are ensured in sorted order. The record must implement KeysGetterSetter, as GetterSetterMap and Headers
+// do, or EnsurePrefix panics. Since the attributes depend on the record they are not included by RecordEnsurer.Rules
+// or RecordEnsurer.JSONSchema.
+func (r *RecordWithErrors) EnsurePrefix(prefix string, ensurers ...Ensurer) {
+	keys, ok := recordKeys(r.record)
+	if !ok {
+		panic(fmt.Errorf("%T does not implement KeysGetterSetter", r.record))
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			r.Ensure(key, ensurers...)
+		}
+	}
+}
+
+// recordKeys returns the keys of record if it implements KeysGetterSetter.
+func recordKeys(record GetterSetter) ([]string, bool) {
+	if record, ok := unwrapRecord(record).(KeysGetterSetter); ok {
+		return record.Keys(), true
+	}
+	return nil, false
+}
+
+// unwrapRecord returns the record wrapped by CaptureFailures or record itself if it is not wrapped.
+func unwrapRecord(record GetterSetter) GetterSetter {
+	if recorder, ok := record.(*recordingGetterSetter); ok {
+		return recorder.GetterSetter
+	}
+	return record
+}
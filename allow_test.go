@@ -0,0 +1,107 @@
+package ensure_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldsRecord is a GetterSetter that does not implement KeysGetterSetter.
+type fieldsRecord struct {
+	fields map[string]any
+}
+
+func (r *fieldsRecord) Get(field string) any        { return r.fields[field] }
+func (r *fieldsRecord) Set(field string, value any) { r.fields[field] = value }
+
+func TestAllowOnly(t *testing.T) {
+	fn := func(r *ensure.RecordWithErrors) {
+		r.AllowOnly("name", "age")
+		r.Ensure("name", ensure.String())
+		r.Ensure("age", ensure.Int32())
+	}
+
+	err := ensure.Record(ensure.GetterSetterMap{"name": "Jack", "age": "42"}, fn)
+	require.NoError(t, err)
+
+	err = ensure.Record(ensure.GetterSetterMap{"name": "Jack"}, fn)
+	require.NoError(t, err)
+
+	err = ensure.Record(ensure.GetterSetterMap{"name": "Jack", "admin": true, "Age": "42"}, fn)
+	require.Error(t, err)
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	var fields []string
+	for _, fe := range recordErrs.FieldErrors() {
+		fields = append(fields, fe.Path.String())
+		var ensureErr *ensure.Error
+		require.ErrorAs(t, fe.Err, &ensureErr)
+		assert.Equal(t, "unknown_field", ensureErr.Code)
+	}
+	assert.Equal(t, []string{"Age", "admin"}, fields)
+
+	_, err = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.AllowOnly("city")
+	}).CaptureFailures(&bytes.Buffer{}).Ensure(map[string]any{"city": "Dallas", "zip": "75201"})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"zip"}), 1)
+
+	assert.Panics(t, func() {
+		ensure.Record(&fieldsRecord{}, func(r *ensure.RecordWithErrors) {
+			r.AllowOnly("name")
+		})
+	})
+}
+
+func TestPermit(t *testing.T) {
+	record := ensure.GetterSetterMap{"name": "Jack", "age": "42", "admin": true, "role": "owner"}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Permit("name", "age", "email")
+		r.Ensure("name", ensure.String())
+		r.Ensure("age", ensure.Int32())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{"name": "Jack", "age": int32(42)}, record)
+
+	record = ensure.GetterSetterMap{"name": "Jack", "admin": true}
+	_, err = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Permit("name")
+	}).CaptureFailures(&bytes.Buffer{}).Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{"name": "Jack"}, record)
+
+	assert.Panics(t, func() {
+		ensure.Record(&fieldsRecord{}, func(r *ensure.RecordWithErrors) {
+			r.Permit("name")
+		})
+	})
+}
+
+func TestEnsurePrefix(t *testing.T) {
+	record := ensure.GetterSetterMap{"X-Count": "3", "X-Limit": "10", "Host": "example.com"}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.EnsurePrefix("X-", ensure.Int32())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{"X-Count": int32(3), "X-Limit": int32(10), "Host": "example.com"}, record)
+
+	err = ensure.Record(ensure.GetterSetterMap{"X-Count": "abc", "Host": "example.com"}, func(r *ensure.RecordWithErrors) {
+		r.EnsurePrefix("X-", ensure.Int32())
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Len(t, recordErrs.ByField()["X-Count"], 1)
+	assert.Len(t, recordErrs.FieldErrors(), 1)
+
+	assert.Panics(t, func() {
+		ensure.Record(&fieldsRecord{}, func(r *ensure.RecordWithErrors) {
+			r.EnsurePrefix("X-")
+		})
+	})
+}
@@ -0,0 +1,213 @@
+package ensure
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"strings"
+)
+
+// ArchiveSafety returns a Ensurer that validates value is a zip, tar, or gzip compressed tar archive that is safe to
+// extract. It rejects archives with more than maxEntries entries, archives that decompress to more than
+// maxUncompressedSize bytes, and entries whose paths or link targets are absolute or escape the extraction directory
+// (zip-slip). Entries are actually decompressed to count their size, so the sizes recorded in the archive headers are
+// not trusted. value must be a []byte, *multipart.FileHeader, or a value such as *os.File that implements io.ReaderAt
+// and io.Seeker. value is returned unmodified. If value is nil then nil is returned.
+func ArchiveSafety(maxEntries int, maxUncompressedSize int64) Ensurer {
+	ensurer := EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		r, closeUpload, err := openUpload(value)
+		if err != nil {
+			return nil, err
+		}
+		defer closeUpload()
+
+		checker := &archiveChecker{maxEntries: maxEntries, maxUncompressedSize: maxUncompressedSize}
+
+		magic := make([]byte, 4)
+		n, _ := r.ReadAt(magic, 0)
+		magic = magic[:n]
+
+		switch {
+		case bytes.HasPrefix(magic, []byte("PK\x03\x04")) || bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+			err = checker.checkZip(r)
+		case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+			var gz *gzip.Reader
+			gz, err = gzip.NewReader(r)
+			if err == nil {
+				err = checker.checkTar(gz)
+			}
+		default:
+			err = checker.checkTar(r)
+		}
+
+		if err != nil {
+			var ensureErr *Error
+			if errors.As(err, &ensureErr) {
+				return nil, ensureErr
+			}
+			return nil, NewError("invalid_archive", "not a valid archive", nil)
+		}
+
+		return value, nil
+	})
+
+	params := map[string]any{"max_entries": maxEntries, "max_uncompressed_size": maxUncompressedSize}
+	return describe("archivesafety", params, ensurer)
+}
+
+type archiveChecker struct {
+	maxEntries          int
+	maxUncompressedSize int64
+
+	entries          int
+	uncompressedSize int64
+}
+
+func (ac *archiveChecker) checkEntry(name, linkTarget string) error {
+	ac.entries++
+	if ac.entries > ac.maxEntries {
+		return NewError("archive_too_many_entries", "archive has too many entries", map[string]any{"max": ac.maxEntries})
+	}
+
+	if unsafeArchivePath(name) {
+		return NewError("unsafe_archive_path", "archive contains an unsafe path", map[string]any{"path": name})
+	}
+
+	if linkTarget != "" && unsafeArchivePath(linkTarget) {
+		return NewError("unsafe_archive_path", "archive contains an unsafe link", map[string]any{"path": name})
+	}
+
+	return nil
+}
+
+// count reads r to EOF while counting against the remaining uncompressed size budget.
+func (ac *archiveChecker) count(r io.Reader) error {
+	// Read one byte more than the remaining size to detect exceeding it unless that would overflow.
+	limit := ac.maxUncompressedSize - ac.uncompressedSize
+	if limit < math.MaxInt64 {
+		limit++
+	}
+	n, err := io.Copy(io.Discard, io.LimitReader(r, limit))
+	ac.uncompressedSize += n
+	if err != nil {
+		return err
+	}
+	if ac.uncompressedSize > ac.maxUncompressedSize {
+		return NewError("archive_too_large", "archive uncompressed size too large", map[string]any{"max": ac.maxUncompressedSize})
+	}
+
+	return nil
+}
+
+func (ac *archiveChecker) checkZip(r *io.SectionReader) error {
+	zr, err := zip.NewReader(r, r.Size())
+	if err != nil {
+		return err
+	}
+
+	if len(zr.File) > ac.maxEntries {
+		return NewError("archive_too_many_entries", "archive has too many entries", map[string]any{"max": ac.maxEntries})
+	}
+
+	for _, f := range zr.File {
+		var linkTarget string
+		if f.Mode()&fs.ModeSymlink != 0 {
+			target, err := ac.readZipFile(f)
+			if err != nil {
+				return err
+			}
+			linkTarget = target
+		}
+
+		err := ac.checkEntry(f.Name, linkTarget)
+		if err != nil {
+			return err
+		}
+
+		if linkTarget == "" {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			err = ac.count(rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ac *archiveChecker) readZipFile(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	buf := &bytes.Buffer{}
+	err = ac.count(io.TeeReader(rc, buf))
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (ac *archiveChecker) checkTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			if ac.entries == 0 {
+				return errors.New("empty or invalid tar archive")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var linkTarget string
+		if header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink {
+			linkTarget = header.Linkname
+		}
+
+		err = ac.checkEntry(header.Name, linkTarget)
+		if err != nil {
+			return err
+		}
+
+		err = ac.count(tr)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// unsafeArchivePath reports whether name is absolute or contains a ".." element.
+func unsafeArchivePath(name string) bool {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || (len(name) >= 2 && name[1] == ':') {
+		return true
+	}
+
+	for _, element := range strings.Split(name, "/") {
+		if element == ".." {
+			return true
+		}
+	}
+
+	return false
+}
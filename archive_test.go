@@ -0,0 +1,125 @@
+package ensure_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveEntry struct {
+	name    string
+	content string
+	link    string
+}
+
+func buildZip(t *testing.T, entries []archiveEntry) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		content := e.content
+		if e.link != "" {
+			header.SetMode(fs.ModeSymlink | 0o777)
+			content = e.link
+		}
+		w, err := zw.CreateHeader(header)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func buildTarGz(t *testing.T, entries []archiveEntry) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.link != "" {
+			header = &tar.Header{Name: e.name, Linkname: e.link, Typeflag: tar.TypeSymlink}
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestArchiveSafety(t *testing.T) {
+	safe := []archiveEntry{{name: "a.txt", content: "hello"}, {name: "dir/b.txt", content: "world"}}
+	bomb := []archiveEntry{{name: "bomb.txt", content: strings.Repeat("0", 10000)}}
+	slip := []archiveEntry{{name: "../../etc/passwd", content: "x"}}
+	windowsSlip := []archiveEntry{{name: "..\\evil.exe", content: "x"}}
+	absolute := []archiveEntry{{name: "/etc/passwd", content: "x"}}
+	symlink := []archiveEntry{{name: "link", link: "../../etc"}}
+
+	tests := []struct {
+		value   any
+		code    string
+		success bool
+	}{
+		{buildZip(t, safe), "", true},
+		{buildTarGz(t, safe), "", true},
+		{buildZip(t, bomb), "archive_too_large", false},
+		{buildTarGz(t, bomb), "archive_too_large", false},
+		{buildZip(t, append(safe, safe...)), "archive_too_many_entries", false},
+		{buildTarGz(t, append(safe, safe...)), "archive_too_many_entries", false},
+		{buildZip(t, slip), "unsafe_archive_path", false},
+		{buildZip(t, windowsSlip), "unsafe_archive_path", false},
+		{buildTarGz(t, absolute), "unsafe_archive_path", false},
+		{buildZip(t, symlink), "unsafe_archive_path", false},
+		{buildTarGz(t, symlink), "unsafe_archive_path", false},
+		{[]byte("not an archive"), "invalid_archive", false},
+		{"foo", "not_a_file", false},
+		{nil, "", true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.ArchiveSafety(3, 1000).Ensure(tt.value)
+		if tt.success {
+			assert.NoErrorf(t, err, "%d", i)
+			assert.Equalf(t, tt.value, value, "%d", i)
+		} else {
+			var ensureErr *ensure.Error
+			require.ErrorAsf(t, err, &ensureErr, "%d", i)
+			assert.Equalf(t, tt.code, ensureErr.Code, "%d", i)
+		}
+	}
+}
+
+func TestArchiveSafetyNoSizeLimit(t *testing.T) {
+	safe := buildZip(t, []archiveEntry{{name: "a.txt", content: "hello"}})
+	_, err := ensure.ArchiveSafety(3, math.MaxInt64).Ensure(safe)
+	assert.NoError(t, err)
+
+	// An entry with a wrong checksum is only detected if its content is read.
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name: "a.txt", Method: zip.Store, CRC32: 1, CompressedSize64: 5, UncompressedSize64: 5,
+	})
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	for _, max := range []int64{1000, math.MaxInt64} {
+		_, err = ensure.ArchiveSafety(3, max).Ensure(buf.Bytes())
+		var ensureErr *ensure.Error
+		require.ErrorAsf(t, err, &ensureErr, "%d", max)
+		assert.Equalf(t, "invalid_archive", ensureErr.Code, "%d", max)
+	}
+}
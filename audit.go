@@ -0,0 +1,53 @@
+package ensure
+
+import (
+	"time"
+)
+
+// AuditEvent describes one validation of a record by a RecordEnsurer. It does not include any field values so it can
+// be logged to satisfy requirements to record rejected input without logging the input itself.
+type AuditEvent struct {
+	// RecordType is the name given to RecordEnsurer.Audit.
+	RecordType string
+
+	// Valid is true if the record had no errors.
+	Valid bool
+
+	// ErrorCodes are the codes of the record's errors in the order they were added. Errors that are not an *Error are
+	// reported as "invalid".
+	ErrorCodes []string
+
+	// Coercions is the number of fields whose value was changed by an ensurer, e.g. by converting a string to an int64
+	// or trimming space.
+	Coercions int
+
+	// Duration is how long the record took to ensure.
+	Duration time.Duration
+}
+
+// AuditSink receives an AuditEvent each time an audited RecordEnsurer ensures a record.
+type AuditSink func(AuditEvent)
+
+// Audit returns a copy of re that calls sink with an AuditEvent for every record it ensures. recordType identifies the
+// kind of record in the event, e.g. "signup".
+func (re *RecordEnsurer) Audit(recordType string, sink AuditSink) *RecordEnsurer {
+	newRE := *re
+	newRE.auditRecordType = recordType
+	newRE.auditSink = sink
+	return &newRE
+}
+
+func (re *RecordEnsurer) audit(rwe *RecordWithErrors, err error, duration time.Duration) {
+	event := AuditEvent{
+		RecordType: re.auditRecordType,
+		Valid:      err == nil,
+		Coercions:  rwe.coercions,
+		Duration:   duration,
+	}
+
+	for _, fe := range rwe.fieldErrors {
+		event.ErrorCodes = append(event.ErrorCodes, errorCode(fe.Err))
+	}
+
+	re.auditSink(event)
+}
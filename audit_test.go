@@ -0,0 +1,50 @@
+package ensure_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerAudit(t *testing.T) {
+	var events []ensure.AuditEvent
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.String(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+	}).Then(func(r *ensure.RecordWithErrors) {
+		if r.Get("name") == "admin" {
+			r.Add("name", errors.New("is reserved"))
+		}
+	}).Audit("signup", func(event ensure.AuditEvent) {
+		events = append(events, event)
+	})
+
+	_, err := re.Ensure(map[string]any{"name": "jack", "age": "30"})
+	require.NoError(t, err)
+
+	_, err = re.Ensure(map[string]any{"name": nil, "age": "abc"})
+	require.Error(t, err)
+
+	_, err = re.Ensure(map[string]any{"name": "admin", "age": int32(30)})
+	require.Error(t, err)
+
+	require.Len(t, events, 3)
+	for _, event := range events {
+		assert.Equal(t, "signup", event.RecordType)
+		assert.GreaterOrEqual(t, event.Duration.Nanoseconds(), int64(0))
+	}
+
+	assert.True(t, events[0].Valid)
+	assert.Nil(t, events[0].ErrorCodes)
+	assert.Equal(t, 1, events[0].Coercions)
+
+	assert.False(t, events[1].Valid)
+	assert.Equal(t, []string{"required", "not_a_number"}, events[1].ErrorCodes)
+
+	assert.False(t, events[2].Valid)
+	assert.Equal(t, []string{"invalid"}, events[2].ErrorCodes)
+	assert.Equal(t, 0, events[2].Coercions)
+}
@@ -0,0 +1,120 @@
+package ensure
+
+import (
+	"errors"
+	"sync"
+)
+
+// BatchSummary collects the results of ensuring many records, such as the rows of an import, so a batch job can decide
+// whether to proceed, abort, or partially apply the batch based on how many records failed. The zero value keeps no
+// examples. It is safe for concurrent use.
+type BatchSummary struct {
+	mu sync.Mutex
+
+	maxExamples int
+	total       int
+	failed      int
+	failures    map[BatchFailure]int
+	examples    []BatchExample
+}
+
+// BatchFailure identifies a kind of failure within a batch. Field is the path of the field as returned by Path.String
+// and Code is the Code of the *Error. Errors that are not an *Error have the code "invalid".
+type BatchFailure struct {
+	Field string
+	Code  string
+}
+
+// BatchExample is a failed record kept as an example by BatchSummary.
+type BatchExample struct {
+	Index int
+	Err   error
+}
+
+// NewBatchSummary returns a BatchSummary that keeps the errors of the first maxExamples failed records.
+func NewBatchSummary(maxExamples int) *BatchSummary {
+	return &BatchSummary{maxExamples: maxExamples}
+}
+
+// Add records the result of ensuring the record at index. err is the error returned by Record or RecordEnsurer.Ensure.
+// If err is nil the record is counted as valid.
+func (s *BatchSummary) Add(index int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total++
+	if err == nil {
+		return
+	}
+
+	s.failed++
+	if len(s.examples) < s.maxExamples {
+		s.examples = append(s.examples, BatchExample{Index: index, Err: err})
+	}
+
+	if s.failures == nil {
+		s.failures = map[BatchFailure]int{}
+	}
+
+	var recordErrs *RecordErrors
+	if !errors.As(err, &recordErrs) {
+		s.failures[BatchFailure{Code: errorCode(err)}]++
+		return
+	}
+
+	for _, fe := range recordErrs.FieldErrors() {
+		s.failures[BatchFailure{Field: fe.Path.String(), Code: errorCode(fe.Err)}]++
+	}
+}
+
+// Total returns the number of records added.
+func (s *BatchSummary) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.total
+}
+
+// Failed returns the number of records added with an error.
+func (s *BatchSummary) Failed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed
+}
+
+// FailureRate returns the fraction of records added that failed. It is 0 if no records have been added.
+func (s *BatchSummary) FailureRate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.total == 0 {
+		return 0
+	}
+	return float64(s.failed) / float64(s.total)
+}
+
+// Failures returns the number of errors of each kind. A record with several errors counts toward each of them.
+func (s *BatchSummary) Failures() map[BatchFailure]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failures := make(map[BatchFailure]int, len(s.failures))
+	for k, v := range s.failures {
+		failures[k] = v
+	}
+	return failures
+}
+
+// Examples returns the first failed records in the order they were added.
+func (s *BatchSummary) Examples() []BatchExample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]BatchExample(nil), s.examples...)
+}
+
+// errorCode returns the Code of err if it is an *Error and "invalid" otherwise.
+func errorCode(err error) string {
+	var ensureErr *Error
+	if errors.As(err, &ensureErr) {
+		return ensureErr.Code
+	}
+	return "invalid"
+}
@@ -0,0 +1,47 @@
+package ensure_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSummary(t *testing.T) {
+	rows := []map[string]any{
+		{"name": "Jack", "age": "42"},
+		{"name": "", "age": "abc"},
+		{"name": "Jill", "age": "abc"},
+		{"name": "Joe", "age": "30"},
+	}
+
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+	})
+
+	summary := ensure.NewBatchSummary(1)
+	assert.Equal(t, 0.0, summary.FailureRate())
+
+	for i, row := range rows {
+		_, err := re.Ensure(row)
+		summary.Add(i, err)
+	}
+	summary.Add(len(rows), errors.New("unreadable row"))
+
+	assert.Equal(t, 5, summary.Total())
+	assert.Equal(t, 3, summary.Failed())
+	assert.Equal(t, 0.6, summary.FailureRate())
+	assert.Equal(t, map[ensure.BatchFailure]int{
+		{Field: "name", Code: "required"}:    1,
+		{Field: "age", Code: "not_a_number"}: 2,
+		{Code: "invalid"}:                    1,
+	}, summary.Failures())
+
+	examples := summary.Examples()
+	require.Len(t, examples, 1)
+	assert.Equal(t, 1, examples[0].Index)
+	assert.Error(t, examples[0].Err)
+}
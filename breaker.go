@@ -0,0 +1,129 @@
+package ensure
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BreakerFallback is what a Breaker returns instead of calling its inner ensurer while it is open.
+type BreakerFallback int
+
+const (
+	// BreakerSkip returns an error matching ErrNotChecked so the field is reported as a warning by CollectWarnings
+	// instead of failing.
+	BreakerSkip BreakerFallback = iota
+
+	// BreakerFail fails the field with the code "unavailable".
+	BreakerFail
+)
+
+// BreakerOptions configures Breaker.
+type BreakerOptions struct {
+	// Threshold is the number of consecutive failures that opens the breaker. It defaults to 5.
+	Threshold int
+
+	// Cooldown is how long the breaker stays open before a single trial call is made. If the trial succeeds the
+	// breaker closes. Otherwise it stays open for another Cooldown. It defaults to 30 seconds.
+	Cooldown time.Duration
+
+	// Fallback is returned while the breaker is open.
+	Fallback BreakerFallback
+
+	// IsFailure returns true if err is a failure of the service rather than an answer about the value. It defaults to
+	// IsRetryable.
+	IsFailure func(err error) bool
+}
+
+// Breaker returns a Ensurer that calls inner until it fails opts.Threshold times in a row and then stops calling it
+// for opts.Cooldown, returning opts.Fallback instead. It is intended for ensurers backed by a verification service so
+// a service that is down does not add its timeout to every request. Failures are counted across every record ensured
+// with the returned Ensurer, so it should be created once and shared. Failures caused by the context of the record
+// being done are not counted.
+func Breaker(inner Ensurer, opts BreakerOptions) Ensurer {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = IsRetryable
+	}
+
+	b := &breaker{opts: opts}
+
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if !b.allow() {
+			return nil, b.fallback()
+		}
+
+		result, err := ensureContext(ctx, inner, value)
+		if err != nil && ctx.Err() != nil {
+			b.release()
+		} else {
+			b.done(err != nil && opts.IsFailure(err))
+		}
+		return result, err
+	})
+
+	return describe("breaker", map[string]any{"ensurers": []Ensurer{inner}, "threshold": opts.Threshold}, ensurer)
+}
+
+// breaker is the state of a Breaker.
+type breaker struct {
+	opts BreakerOptions
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// allow returns true if a call may be made. While the breaker is open only a single trial call is allowed after the
+// cooldown.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.opts.Threshold {
+		return true
+	}
+	if b.trial || time.Now().Before(b.openUntil) {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+// done records the result of a call.
+func (b *breaker) done(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.opts.Threshold {
+		b.openUntil = time.Now().Add(b.opts.Cooldown)
+	}
+}
+
+// release ends a call whose result says nothing about the service such as one interrupted by its context.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *breaker) fallback() error {
+	if b.opts.Fallback == BreakerFail {
+		return NewError("unavailable", "could not be checked", nil)
+	}
+	return NewError("not_checked", "was not checked: service unavailable", map[string]any{"reason": "breaker"})
+}
@@ -0,0 +1,79 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	errDown := errors.New("service unavailable")
+	down := true
+	calls := 0
+	verify := ensure.EnsurerFunc(func(value any) (any, error) {
+		calls++
+		if down {
+			return nil, errDown
+		}
+		if value == "bogus" {
+			return nil, ensure.NewError("unverified", "could not be verified", nil)
+		}
+		return value, nil
+	})
+
+	breaker := ensure.Breaker(verify, ensure.BreakerOptions{Threshold: 2, Cooldown: 20 * time.Millisecond})
+
+	for i := 0; i < 2; i++ {
+		_, err := breaker.Ensure("x")
+		assert.ErrorIs(t, err, errDown)
+	}
+	assert.Equal(t, 2, calls)
+
+	_, err := breaker.Ensure("x")
+	assert.ErrorIs(t, err, ensure.ErrNotChecked)
+	assert.Equal(t, 2, calls)
+
+	ctx, warnings := ensure.CollectWarnings(context.Background())
+	record := ensure.GetterSetterMap{"address": "x"}
+	err = ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("address", breaker, ensure.Require())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "x", record["address"])
+	require.Len(t, warnings.List(), 1)
+	assert.Equal(t, "address", warnings.List()[0].Path.String())
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = breaker.Ensure("x")
+	assert.ErrorIs(t, err, errDown)
+	assert.Equal(t, 3, calls)
+	_, err = breaker.Ensure("x")
+	assert.ErrorIs(t, err, ensure.ErrNotChecked)
+
+	down = false
+	time.Sleep(30 * time.Millisecond)
+	value, err := breaker.Ensure("x")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", value)
+	for i := 0; i < 3; i++ {
+		_, err = breaker.Ensure("bogus")
+		var ensureErr *ensure.Error
+		require.ErrorAs(t, err, &ensureErr)
+		assert.Equal(t, "unverified", ensureErr.Code)
+	}
+	assert.Equal(t, 7, calls)
+
+	down = true
+	failing := ensure.Breaker(verify, ensure.BreakerOptions{Threshold: 1, Fallback: ensure.BreakerFail})
+	_, err = failing.Ensure("x")
+	assert.ErrorIs(t, err, errDown)
+	_, err = failing.Ensure("x")
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "unavailable", ensureErr.Code)
+}
@@ -0,0 +1,118 @@
+package ensure
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Budget limits the external checks made while ensuring a record. See RecordEnsurer.WithBudget.
+type Budget struct {
+	// MaxCalls is the maximum number of external checks. 0 means no limit.
+	MaxCalls int
+
+	// MaxDuration is the maximum total time spent in external checks. Each check is given a context with a deadline of
+	// the time that remains. 0 means no limit.
+	MaxDuration time.Duration
+}
+
+// WithBudget returns a copy of re that limits the External checks made while ensuring a record, including the checks
+// of nested records, to budget. Once the budget is used up the remaining external checks are not made and return an
+// error matching ErrNotChecked, so the record is not failed or blocked by a slow or overloaded service. Such fields
+// are reported as warnings by CollectWarnings. A check that exceeds the time that remains is also not checked.
+//
+// If the record is nested in a record that has a budget then the budget of the outer record is used.
+func (re *RecordEnsurer) WithBudget(budget Budget) *RecordEnsurer {
+	newRE := *re
+	newRE.budget = &budget
+	return &newRE
+}
+
+// budgetContext returns ctx with the budget of re if re has a budget and ctx does not already have one.
+func (re *RecordEnsurer) budgetContext(ctx context.Context) context.Context {
+	if re.budget == nil {
+		return ctx
+	}
+	if _, ok := ctx.Value(budgetKey{}).(*budgetState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, &budgetState{budget: *re.budget})
+}
+
+type budgetKey struct{}
+
+var errBudgetExhausted = NewError("not_checked", "was not checked: budget exhausted", map[string]any{
+	"reason": "budget",
+})
+
+// budgetState is the use of a Budget by a record.
+type budgetState struct {
+	mu     sync.Mutex
+	budget Budget
+	calls  int
+	spent  time.Duration
+}
+
+// reserve reserves a call. It returns the time that remains, which is 0 if there is no MaxDuration, and false if the
+// budget is used up.
+func (bs *budgetState) reserve() (time.Duration, bool) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if bs.budget.MaxCalls > 0 && bs.calls >= bs.budget.MaxCalls {
+		return 0, false
+	}
+
+	var remaining time.Duration
+	if bs.budget.MaxDuration > 0 {
+		remaining = bs.budget.MaxDuration - bs.spent
+		if remaining <= 0 {
+			return 0, false
+		}
+	}
+
+	bs.calls++
+	return remaining, true
+}
+
+func (bs *budgetState) spend(d time.Duration) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.spent += d
+}
+
+// External returns a Ensurer that marks e as an external check such as a database query or API call so it is limited
+// by the Budget of the record. e should usually be an EnsurerContext so it can honor the deadline of the time that
+// remains. If the record has no Budget then e is called unmodified. Unique is always an external check.
+func External(e Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		bs, ok := ctx.Value(budgetKey{}).(*budgetState)
+		if !ok {
+			return ensureContext(ctx, e, value)
+		}
+
+		remaining, ok := bs.reserve()
+		if !ok {
+			return nil, errBudgetExhausted
+		}
+
+		callCtx := ctx
+		if remaining > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, remaining)
+			defer cancel()
+		}
+
+		start := time.Now()
+		result, err := ensureContext(callCtx, e, value)
+		bs.spend(time.Since(start))
+
+		if err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+			return nil, errBudgetExhausted
+		}
+
+		return result, err
+	})
+
+	return describe("external", map[string]any{"ensurers": []Ensurer{e}}, ensurer)
+}
@@ -0,0 +1,61 @@
+package ensure_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerWithBudget(t *testing.T) {
+	var calls atomic.Int32
+	lookup := ensure.External(ensure.EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		calls.Add(1)
+		if value == "slow" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return value, nil
+	}))
+
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("a", lookup)
+		r.Ensure("b", lookup)
+		r.Ensure("c", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("d", lookup, ensure.Require())
+		}))
+	})
+
+	ctx, warnings := ensure.CollectWarnings(context.Background())
+	_, err := re.WithBudget(ensure.Budget{MaxCalls: 2}).EnsureContext(ctx, map[string]any{
+		"a": "x", "b": "y", "c": map[string]any{"d": "z"},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+	require.Len(t, warnings.List(), 1)
+	assert.Equal(t, "c.d", warnings.List()[0].Path.String())
+	assert.ErrorIs(t, warnings.List()[0].Err, ensure.ErrNotChecked)
+
+	start := time.Now()
+	ctx, warnings = ensure.CollectWarnings(context.Background())
+	_, err = re.WithBudget(ensure.Budget{MaxDuration: 20 * time.Millisecond}).EnsureContext(ctx, map[string]any{
+		"a": "slow", "b": "y", "c": map[string]any{"d": "z"},
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	require.NotEmpty(t, warnings.List())
+	assert.Equal(t, "a", warnings.List()[0].Path.String())
+
+	calls.Store(0)
+	_, err = re.Ensure(map[string]any{"a": "x", "b": "y", "c": map[string]any{"d": "z"}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, calls.Load())
+
+	report, err := re.WithBudget(ensure.Budget{MaxCalls: 1}).Check(map[string]any{"a": "x", "b": "y"})
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+}
@@ -0,0 +1,151 @@
+package ensure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// CaptureFailures returns a copy of re that writes each record that fails to w as a line of JSON so hard to reproduce
+// validation failures can be replayed in tests. Each line has an "input" object holding the original value of every
+// field the record's ensurers read and an "errors" array with the "field", "label", "code", and "message" of each
+// error. Fields named in redact, at any depth, have their value replaced with "[REDACTED]". Values that cannot be
+// marshaled to JSON are written as strings. Errors writing to w are ignored. It is intended for debugging and is safe
+// for concurrent use.
+func (re *RecordEnsurer) CaptureFailures(w io.Writer, redact ...string) *RecordEnsurer {
+	fc := &failureCapture{w: w, redact: make(map[string]struct{}, len(redact))}
+	for _, field := range redact {
+		fc.redact[field] = struct{}{}
+	}
+
+	newRE := *re
+	newRE.failureCapture = fc
+	return &newRE
+}
+
+// recordingGetterSetter wraps a GetterSetter and remembers the first value read for each field.
+type recordingGetterSetter struct {
+	GetterSetter
+	originals map[string]any
+}
+
+func (r *recordingGetterSetter) Get(field string) any {
+	value := r.GetterSetter.Get(field)
+	if _, ok := r.originals[field]; !ok {
+		r.originals[field] = copyValue(value)
+	}
+	return value
+}
+
+// copyValue returns a deep copy of maps and slices in value so later changes by nested ensurers are not seen.
+func copyValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for k, v := range value {
+			m[k] = copyValue(v)
+		}
+		return m
+	case GetterSetterMap:
+		return copyValue(map[string]any(value))
+	case []any:
+		s := make([]any, len(value))
+		for i, v := range value {
+			s[i] = copyValue(v)
+		}
+		return s
+	}
+	return value
+}
+
+type failureCapture struct {
+	mu     sync.Mutex
+	w      io.Writer
+	redact map[string]struct{}
+}
+
+type capturedFailure struct {
+	Index  *int            `json:"index,omitempty"`
+	Input  map[string]any  `json:"input"`
+	Errors []capturedError `json:"errors"`
+}
+
+type capturedError struct {
+	Field   string `json:"field"`
+	Label   string `json:"label,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (fc *failureCapture) capture(input map[string]any, fieldErrors []*FieldError) {
+	failure := capturedFailure{
+		Input:  fc.redactMap(input),
+		Errors: captureErrors(fieldErrors),
+	}
+
+	buf, err := failure.marshal()
+	if err != nil {
+		return
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.w.Write(buf)
+}
+
+func captureErrors(fieldErrors []*FieldError) []capturedError {
+	captured := make([]capturedError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		captured[i] = capturedError{Field: fe.Path.String(), Label: fe.Label, Message: fe.Err.Error()}
+		var ensureErr *Error
+		if errors.As(fe.Err, &ensureErr) {
+			captured[i].Code = ensureErr.Code
+		}
+	}
+	return captured
+}
+
+// marshal returns cf as a line of JSON. Input values that cannot be marshaled are converted to strings.
+func (cf *capturedFailure) marshal() ([]byte, error) {
+	buf, err := json.Marshal(cf)
+	if err != nil {
+		for field, value := range cf.Input {
+			if _, err := json.Marshal(value); err != nil {
+				cf.Input[field] = fmt.Sprint(value)
+			}
+		}
+		buf, err = json.Marshal(cf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '\n'), nil
+}
+
+func (fc *failureCapture) redactMap(m map[string]any) map[string]any {
+	redacted := make(map[string]any, len(m))
+	for field, value := range m {
+		if _, ok := fc.redact[field]; ok {
+			redacted[field] = "[REDACTED]"
+		} else {
+			redacted[field] = fc.redactValue(value)
+		}
+	}
+	return redacted
+}
+
+func (fc *failureCapture) redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		return fc.redactMap(value)
+	case []any:
+		redacted := make([]any, len(value))
+		for i, v := range value {
+			redacted[i] = fc.redactValue(v)
+		}
+		return redacted
+	}
+	return value
+}
@@ -0,0 +1,59 @@
+package ensure_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerCaptureFailures(t *testing.T) {
+	buf := &bytes.Buffer{}
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", ensure.String(), ensure.Require())
+		r.Ensure("password", ensure.String(), ensure.MinLen(8))
+		r.Ensure("age", ensure.Int32())
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("zip", ensure.Int32())
+			r.Ensure("city", ensure.SingleLineString())
+		}))
+	}).CaptureFailures(buf, "password", "ssn")
+
+	_, err := re.Ensure(map[string]any{"email": "jack@example.com", "password": "correct horse", "age": "30"})
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	_, err = re.Ensure(map[string]any{
+		"email":    "jack@example.com",
+		"password": "short",
+		"age":      "abc",
+		"address":  map[string]any{"zip": "x", "city": " Dallas ", "ssn": "123-45-6789"},
+	})
+	require.Error(t, err)
+
+	_, err = re.Ensure(map[string]any{"age": math.Inf(1)})
+	require.Error(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"input": {
+			"email": "jack@example.com",
+			"password": "[REDACTED]",
+			"age": "abc",
+			"address": {"zip": "x", "city": " Dallas ", "ssn": "[REDACTED]"}
+		},
+		"errors": [
+			{"field": "password", "code": "too_short", "message": "too short"},
+			{"field": "age", "code": "not_a_number", "message": "not a valid number"},
+			{"field": "address.zip", "code": "not_a_number", "message": "not a valid number"}
+		]
+	}`, lines[0])
+	assert.Contains(t, lines[1], `"age":"+Inf"`)
+	assert.NotContains(t, buf.String(), "123-45-6789")
+	assert.NotContains(t, buf.String(), `"short"`)
+}
@@ -0,0 +1,90 @@
+package ensure
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lower returns a Ensurer that converts value to lower case. It is intended for normalizing values such as email
+// addresses and usernames. If value is nil then nil is returned. If value is not a string then an error is returned.
+func Lower() Ensurer {
+	return describe("lower", nil, transformString(strings.ToLower))
+}
+
+// Upper returns a Ensurer that converts value to upper case. If value is nil then nil is returned. If value is not a
+// string then an error is returned.
+func Upper() Ensurer {
+	return describe("upper", nil, transformString(strings.ToUpper))
+}
+
+// TitleCase returns a Ensurer that converts the first letter of each space separated word in value to title case and
+// the rest to lower case. e.g. "JOHN smith" becomes "John Smith". If value is nil then nil is returned. If value is not
+// a string then an error is returned.
+func TitleCase() Ensurer {
+	return describe("titlecase", nil, transformString(func(s string) string {
+		sb := &strings.Builder{}
+		sb.Grow(len(s))
+		startOfWord := true
+		for _, r := range s {
+			if unicode.IsSpace(r) {
+				startOfWord = true
+				sb.WriteRune(r)
+				continue
+			}
+
+			if startOfWord {
+				sb.WriteRune(unicode.ToTitle(r))
+				startOfWord = false
+			} else {
+				sb.WriteRune(unicode.ToLower(r))
+			}
+		}
+		return sb.String()
+	}))
+}
+
+// CamelToSnake returns a Ensurer that converts value from camelCase or PascalCase to snake_case. Runs of capitals are
+// treated as one word. e.g. "userID" becomes "user_id" and "HTTPServer" becomes "http_server". If value is nil then
+// nil is returned. If value is not a string then an error is returned.
+func CamelToSnake() Ensurer {
+	return describe("cameltosnake", nil, transformString(func(s string) string {
+		runes := []rune(s)
+		sb := &strings.Builder{}
+		sb.Grow(len(s) + 4)
+		for i, r := range runes {
+			if unicode.IsUpper(r) && i > 0 {
+				prev := runes[i-1]
+				nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+					sb.WriteByte('_')
+				}
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		}
+		return sb.String()
+	}))
+}
+
+// SnakeToCamel returns a Ensurer that converts value from snake_case to lower camelCase. e.g. "user_id" becomes
+// "userId". Leading, trailing, and repeated underscores are dropped. If value is nil then nil is returned. If value is
+// not a string then an error is returned.
+func SnakeToCamel() Ensurer {
+	return describe("snaketocamel", nil, transformString(func(s string) string {
+		sb := &strings.Builder{}
+		sb.Grow(len(s))
+		for _, word := range strings.Split(s, "_") {
+			if word == "" {
+				continue
+			}
+			if sb.Len() == 0 {
+				sb.WriteString(word)
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(word)
+			sb.WriteRune(unicode.ToUpper(r))
+			sb.WriteString(word[size:])
+		}
+		return sb.String()
+	}))
+}
@@ -0,0 +1,38 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseTransformations(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Lower(), "Jack@Example.COM", "jack@example.com", true},
+		{ensure.Upper(), "abc-123", "ABC-123", true},
+		{ensure.TitleCase(), "JOHN smith", "John Smith", true},
+		{ensure.TitleCase(), "  élan  vital", "  Élan  Vital", true},
+		{ensure.CamelToSnake(), "userID", "user_id", true},
+		{ensure.CamelToSnake(), "HTTPServer", "http_server", true},
+		{ensure.CamelToSnake(), "FirstName", "first_name", true},
+		{ensure.CamelToSnake(), "address2Line", "address2_line", true},
+		{ensure.CamelToSnake(), "already_snake", "already_snake", true},
+		{ensure.SnakeToCamel(), "user_id", "userId", true},
+		{ensure.SnakeToCamel(), "_first__name_", "firstName", true},
+		{ensure.SnakeToCamel(), "plain", "plain", true},
+		{ensure.Lower(), 42, nil, false},
+		{ensure.Upper(), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
@@ -0,0 +1,67 @@
+package ensure
+
+import (
+	"unicode"
+)
+
+// Alpha returns a Ensurer that returns an error unless every rune in value is a letter or one of extra. Letters are
+// Unicode letters; combine with ASCII to allow only A-Z and a-z. If value is nil then nil is returned. If value is not
+// a string then an error is returned.
+func Alpha(extra ...rune) Ensurer {
+	return charClass("alpha", unicode.IsLetter, extra, "not_alpha", "must contain only letters")
+}
+
+// Alphanumeric returns a Ensurer that returns an error unless every rune in value is a letter, a digit, or one of
+// extra. e.g. Alphanumeric('-', '_') for usernames. Otherwise it behaves like Alpha.
+func Alphanumeric(extra ...rune) Ensurer {
+	return charClass("alphanumeric", func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }, extra,
+		"not_alphanumeric", "must contain only letters and digits")
+}
+
+// Numeric returns a Ensurer that returns an error unless every rune in value is a decimal digit or one of extra. Unlike
+// the number ensurers it does not convert value so leading zeros in codes and reference numbers are kept. Digits are
+// Unicode decimal digits; combine with ASCII to allow only 0-9. Otherwise it behaves like Alpha.
+func Numeric(extra ...rune) Ensurer {
+	return charClass("numeric", unicode.IsDigit, extra, "not_numeric", "must contain only digits")
+}
+
+// ASCII returns a Ensurer that returns an error unless every rune in value is ASCII or one of extra. Otherwise it
+// behaves like Alpha.
+func ASCII(extra ...rune) Ensurer {
+	return charClass("ascii", func(r rune) bool { return r <= unicode.MaxASCII }, extra, "not_ascii",
+		"must contain only ASCII characters")
+}
+
+// PrintableASCII returns a Ensurer that returns an error unless every rune in value is a printable ASCII character,
+// space through tilde, or one of extra. Otherwise it behaves like Alpha.
+func PrintableASCII(extra ...rune) Ensurer {
+	return charClass("printableascii", func(r rune) bool { return r >= ' ' && r <= '~' }, extra, "not_printable_ascii",
+		"must contain only printable ASCII characters")
+}
+
+// charClass returns a Ensurer of kind that fails with code and message unless every rune of value is allowed or in
+// extra.
+func charClass(kind string, allowed func(rune) bool, extra []rune, code, message string) Ensurer {
+	extraSet := make(map[rune]struct{}, len(extra))
+	for _, r := range extra {
+		extraSet[r] = struct{}{}
+	}
+
+	var params map[string]any
+	if len(extra) > 0 {
+		params = map[string]any{"extra": string(extra)}
+	}
+
+	return describe(kind, params, stringConstraint(func(s string) bool {
+		for _, r := range s {
+			if allowed(r) {
+				continue
+			}
+			if _, ok := extraSet[r]; ok {
+				continue
+			}
+			return false
+		}
+		return true
+	}, code, message, params))
+}
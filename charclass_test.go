@@ -0,0 +1,47 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharacterClasses(t *testing.T) {
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		success bool
+	}{
+		{ensure.Alpha(), "abcXYZ", true},
+		{ensure.Alpha(), "José", true},
+		{ensure.Alpha(), "abc1", false},
+		{ensure.Alpha(' ', '-'), "Mary-Jane Smith", true},
+		{ensure.Alphanumeric(), "abc123", true},
+		{ensure.Alphanumeric(), "abc_123", false},
+		{ensure.Alphanumeric('-', '_'), "abc_123-x", true},
+		{ensure.Numeric(), "000123", true},
+		{ensure.Numeric(), "12.5", false},
+		{ensure.Numeric('-'), "555-1234", true},
+		{ensure.ASCII(), "hello\tworld\n", true},
+		{ensure.ASCII(), "héllo", false},
+		{ensure.ASCII('é'), "héllo", true},
+		{ensure.PrintableASCII(), "Hello, World! ~", true},
+		{ensure.PrintableASCII(), "hello\tworld", false},
+		{ensure.PrintableASCII('\t'), "hello\tworld", true},
+		{ensure.Alpha(), "", true},
+		{ensure.Alpha(), 42, false},
+		{ensure.Alpha(), nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			assert.Nilf(t, value, "%d", i)
+			assert.Errorf(t, err, "%d", i)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package ensure
+
+import (
+	"context"
+)
+
+// Report is the result of RecordEnsurer.Check.
+type Report struct {
+	// Values is a copy of the record with the values the ensurers would set. Fields that would fail keep their
+	// original value. If the record does not implement KeysGetterSetter then Values only has the fields that were read
+	// or set.
+	Values map[string]any
+
+	// Errors are the errors the record would fail with. Use RecordErrors.ByField to get them per field. It is nil if
+	// the record is valid.
+	Errors *RecordErrors
+}
+
+// Valid returns true if the record would be ensured without errors.
+func (r *Report) Valid() bool {
+	return r.Errors == nil
+}
+
+// Check runs the ensurers of re like Ensure but leaves value untouched and returns a Report of the values the fields
+// would be set to and the errors the record would fail with. It is intended for "validate" API endpoints and previews
+// of bulk imports. The record is copied first, including nested records and slices, so ensurers such as Nested that
+// modify values in place do not modify value either. Audit and CaptureFailures are not used since nothing is ensured.
+// An error is returned only if value is not a record.
+func (re *RecordEnsurer) Check(value any) (*Report, error) {
+	return re.CheckContext(context.Background(), value)
+}
+
+// CheckContext is like Check but makes ctx available to the record's ensurers. See RecordContext.
+func (re *RecordEnsurer) CheckContext(ctx context.Context, value any) (*Report, error) {
+	var record GetterSetter
+
+	switch value := value.(type) {
+	case GetterSetter:
+		record = value
+	case map[string]any:
+		record = GetterSetterMap(value)
+	default:
+		return nil, NewError("not_a_record", "not a record", nil)
+	}
+
+	var scratch GetterSetter
+	var values map[string]any
+	if kr, ok := record.(KeysGetterSetter); ok {
+		m := make(GetterSetterMap)
+		for _, key := range kr.Keys() {
+			m[key] = copyValue(kr.Get(key))
+		}
+		scratch, values = m, m
+	} else {
+		overlay := &overlayGetterSetter{record: record, values: make(map[string]any)}
+		scratch, values = overlay, overlay.values
+	}
+
+	report := &Report{Values: values}
+	rwe := &RecordWithErrors{ctx: re.budgetContext(ctx), record: scratch, concurrency: re.concurrency}
+	err := runPhases(rwe, re.phases)
+	if err != nil {
+		report.Errors = err.(*RecordErrors)
+	}
+
+	return report, nil
+}
+
+// overlayGetterSetter reads from record and writes to values so record is not modified. Each field is copied with
+// copyValue when it is first read.
+type overlayGetterSetter struct {
+	record GetterSetter
+	values map[string]any
+}
+
+func (o *overlayGetterSetter) Get(field string) any {
+	if value, ok := o.values[field]; ok {
+		return value
+	}
+
+	value := copyValue(o.record.Get(field))
+	o.values[field] = value
+	return value
+}
+
+func (o *overlayGetterSetter) Set(field string, value any) {
+	o.values[field] = value
+}
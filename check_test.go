@@ -0,0 +1,53 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerCheck(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("zip", ensure.Int32())
+		}))
+		r.Permit("name", "age", "address")
+	})
+
+	record := map[string]any{"name": " Jack ", "age": "42", "address": map[string]any{"zip": "12345"}, "admin": true}
+	report, err := re.Check(record)
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.Nil(t, report.Errors)
+	assert.Equal(t, map[string]any{"name": "Jack", "age": int32(42), "address": map[string]any{"zip": int32(12345)}},
+		report.Values)
+	assert.Equal(t, " Jack ", record["name"])
+	assert.Equal(t, map[string]any{"zip": "12345"}, record["address"])
+	assert.Equal(t, true, record["admin"])
+
+	record = map[string]any{"name": "", "age": "abc", "address": map[string]any{"zip": "x"}}
+	report, err = re.Check(record)
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+	require.NotNil(t, report.Errors)
+	assert.Equal(t, []string{"name", "age", "address.zip"}, report.Errors.Fields())
+	assert.Equal(t, "abc", report.Values["age"])
+	assert.Equal(t, map[string]any{"name": "", "age": "abc", "address": map[string]any{"zip": "x"}}, record)
+
+	fr := &fieldsRecord{fields: map[string]any{"name": " Jill ", "age": "30", "other": 1}}
+	report, err = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString())
+		r.EnsureAs("age", "years", ensure.Int32())
+	}).WithConcurrency(2).Check(fr)
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.Equal(t, map[string]any{"name": "Jill", "age": "30", "years": int32(30)}, report.Values)
+	assert.Equal(t, map[string]any{"name": " Jill ", "age": "30", "other": 1}, fr.fields)
+
+	_, err = re.Check(42)
+	assert.Error(t, err)
+}
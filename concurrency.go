@@ -0,0 +1,71 @@
+package ensure
+
+// WithConcurrency returns a copy of re that runs the ensurers of up to n fields at the same time. This speeds up
+// records with several slow ensurers such as Unique or ones that call a remote service. Each call to
+// RecordWithErrors.Ensure reads the field immediately but runs its ensurers in another goroutine. The results are
+// applied to the record in the order Ensure was called when the phase returns, so errors are deterministic. This means
+// that within a phase RecordWithErrors.Get returns the original value of a field, and fields that depend on the
+// converted value of another field must be ensured in a later phase added with Then. The ensurers and record must
+// allow concurrent calls to Ensure and Get. A panic in an ensurer is re-raised in the goroutine that called
+// RecordEnsurer.Ensure. If n is less than 2 the fields are ensured one at a time.
+func (re *RecordEnsurer) WithConcurrency(n int) *RecordEnsurer {
+	newRE := *re
+	newRE.concurrency = n
+	return &newRE
+}
+
+// pendingField is the result of a field being ensured concurrently.
+type pendingField struct {
+	source     string
+	target     string
+	original   any
+	value      any
+	err        error
+	panicValue any
+	panicked   bool
+}
+
+func (r *RecordWithErrors) ensureConcurrently(sourceField, targetField string, ensurers []Ensurer) {
+	if r.sem == nil {
+		r.sem = make(chan struct{}, r.concurrency)
+	}
+
+	pf := &pendingField{source: sourceField, target: targetField, original: r.record.Get(sourceField)}
+	r.pending = append(r.pending, pf)
+
+	r.sem <- struct{}{}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.sem }()
+		defer func() {
+			if pv := recover(); pv != nil {
+				pf.panicValue = pv
+				pf.panicked = true
+			}
+		}()
+
+		pf.value, pf.err = r.ensureValue(sourceField, pf.original, ensurers)
+	}()
+}
+
+// wait waits for all fields being ensured concurrently and applies their results in the order they were started.
+func (r *RecordWithErrors) wait() {
+	if len(r.pending) == 0 {
+		return
+	}
+
+	r.wg.Wait()
+	pending := r.pending
+	r.pending = nil
+
+	for _, pf := range pending {
+		if pf.panicked {
+			panic(pf.panicValue)
+		}
+	}
+
+	for _, pf := range pending {
+		r.setEnsured(pf.source, pf.target, pf.original, pf.value, pf.err)
+	}
+}
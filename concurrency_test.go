@@ -0,0 +1,61 @@
+package ensure_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerWithConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	slow := ensure.EnsurerFunc(func(value any) (any, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return value, nil
+	})
+
+	var seen any
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("a", slow, ensure.Int32())
+		r.Ensure("b", slow, ensure.Int32())
+		r.Ensure("c", slow, ensure.Int32())
+		r.Ensure("d", slow, ensure.Int32())
+	}).Then(func(r *ensure.RecordWithErrors) {
+		seen = r.Get("a")
+	}).WithConcurrency(2)
+
+	record := map[string]any{"a": "1", "b": "2", "c": "3", "d": "4"}
+	_, err := re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": int32(1), "b": int32(2), "c": int32(3), "d": int32(4)}, record)
+	assert.Equal(t, int32(1), seen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+
+	for i := 0; i < 10; i++ {
+		_, err = re.Ensure(map[string]any{"a": "x", "b": "2", "c": "y", "d": "z"})
+		var recordErrs *ensure.RecordErrors
+		require.ErrorAs(t, err, &recordErrs)
+
+		var fields []string
+		for _, fe := range recordErrs.FieldErrors() {
+			fields = append(fields, fe.Path.String())
+		}
+		assert.Equal(t, []string{"a", "c", "d"}, fields)
+	}
+
+	panicky := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("a", ensure.EnsurerFunc(func(value any) (any, error) { panic("boom") }))
+	}).WithConcurrency(2)
+	assert.PanicsWithValue(t, "boom", func() { panicky.Ensure(map[string]any{}) })
+}
@@ -0,0 +1,49 @@
+package ensure
+
+import (
+	"context"
+)
+
+// EnsurerContext is implemented by ensurers that need a context.Context such as those that query a database or call a
+// remote service. RecordWithErrors.Ensure calls EnsureContext instead of Ensure with the context of the record.
+type EnsurerContext interface {
+	Ensurer
+	EnsureContext(ctx context.Context, value any) (any, error)
+}
+
+// EnsurerContextFunc adapts a function to an EnsurerContext. When called through Ensure the function receives
+// context.Background().
+type EnsurerContextFunc func(context.Context, any) (any, error)
+
+func (fn EnsurerContextFunc) Ensure(v any) (any, error) {
+	return fn(context.Background(), v)
+}
+
+func (fn EnsurerContextFunc) EnsureContext(ctx context.Context, v any) (any, error) {
+	return fn(ctx, v)
+}
+
+// RecordContext is like Record but makes ctx available to the ensurers of record. ctx is passed to every
+// EnsurerContext given to RecordWithErrors.Ensure, including through Nested, RecordEnsurer, Slice, Map, and combinators
+// such as All and AnyOf, and is returned by RecordWithErrors.Context. Ensurers are responsible for honoring
+// cancellation themselves.
+func RecordContext(ctx context.Context, record GetterSetter, fn EnsureRecordFunc) error {
+	return recordPhases(ctx, record, []EnsureRecordFunc{fn})
+}
+
+// Context returns the context the record is being ensured with. It is context.Background() unless the record is
+// being ensured with RecordContext or RecordEnsurer.EnsureContext.
+func (r *RecordWithErrors) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// ensureContext calls e with ctx if it is an EnsurerContext.
+func ensureContext(ctx context.Context, e Ensurer, value any) (any, error) {
+	if ec, ok := e.(EnsurerContext); ok {
+		return ec.EnsureContext(ctx, value)
+	}
+	return e.Ensure(value)
+}
@@ -0,0 +1,88 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey struct{}
+
+func TestRecordContext(t *testing.T) {
+	taken := ensure.EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if value == ctx.Value(contextKey{}) {
+			return nil, ensure.NewError("taken", "is already taken", nil)
+		}
+		return value, nil
+	})
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "jack")
+
+	record := ensure.GetterSetterMap{"username": "jack", "profile": map[string]any{"username": "jill"}}
+	err := ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		assert.Equal(t, "jack", r.Context().Value(contextKey{}))
+		r.Ensure("username", ensure.String(), taken)
+		r.Ensure("profile", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("username", taken)
+		}))
+	})
+	require.Error(t, err)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"username"}), 1)
+	assert.Len(t, etErr.Get([]any{"profile", "username"}), 0)
+
+	record = ensure.GetterSetterMap{"profile": map[string]any{"username": "jack"}}
+	_, err = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("profile", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("username", taken)
+		}))
+	}).EnsureContext(ctx, map[string]any(record))
+	require.Error(t, err)
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"profile", "username"}), 1)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ensure.RecordContext(canceledCtx, ensure.GetterSetterMap{"username": "jill"}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("username", taken)
+	})
+	require.Error(t, err)
+	require.ErrorAs(t, err, &etErr)
+	require.Len(t, etErr.Get([]any{"username"}), 1)
+	assert.True(t, errors.Is(etErr.Get([]any{"username"})[0], context.Canceled))
+
+	record = ensure.GetterSetterMap{
+		"members": []any{map[string]any{"username": "jill"}, map[string]any{"username": "jack"}},
+		"owners":  map[string]any{"a": "jack"},
+		"aliases": []any{"jack"},
+	}
+	err = ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("members", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("username", ensure.All(ensure.String(), taken))
+		})))
+		r.Ensure("owners", ensure.Map[string, string](ensure.String(), ensure.IfNotNil(taken)))
+		r.Ensure("aliases", ensure.SliceEach(ensure.When(func(any) bool { return true }, ensure.AnyOf(taken))))
+	})
+	require.Error(t, err)
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"members", 0, "username"}), 0)
+	assert.Len(t, etErr.Get([]any{"members", 1, "username"}), 1)
+	assert.Len(t, etErr.Get([]any{"owners", "a"}), 1)
+	assert.Len(t, etErr.Get([]any{"aliases", 0}), 1)
+
+	err = ensure.Record(ensure.GetterSetterMap{"username": "jack"}, func(r *ensure.RecordWithErrors) {
+		assert.NotNil(t, r.Context())
+		r.Ensure("username", taken)
+	})
+	assert.NoError(t, err)
+}
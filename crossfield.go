@@ -0,0 +1,106 @@
+package ensure
+
+import (
+	"reflect"
+	"time"
+)
+
+// EnsureFieldLessThan ensures that field a is less than field b, e.g. that "start_date" is before "end_date". Both
+// fields must be time.Time values or both must be convertable to decimal numbers. An error is added to b, as that is
+// usually the field the user needs to change. Nothing is checked if either field is nil or already has an error, so it
+// should be called after both fields have been ensured.
+func (r *RecordWithErrors) EnsureFieldLessThan(a, b string) {
+	if r.description != nil {
+		r.description.depend(a, b, "lessthan")
+		return
+	}
+
+	av, bv, ok := r.crossFieldValues(a, b)
+	if !ok {
+		return
+	}
+
+	var less bool
+	at, aIsTime := av.(time.Time)
+	bt, bIsTime := bv.(time.Time)
+	switch {
+	case aIsTime && bIsTime:
+		less = at.Before(bt)
+	case aIsTime || bIsTime:
+		r.Add(b, NewError("invalid_type", "cannot be compared with "+a, map[string]any{"field": a}))
+		return
+	default:
+		ad, aOK := tryDecimal(av)
+		bd, bOK := tryDecimal(bv)
+		if !aOK || !bOK {
+			r.Add(b, NewError("invalid_type", "cannot be compared with "+a, map[string]any{"field": a}))
+			return
+		}
+		less = ad.LessThan(bd)
+	}
+
+	if !less {
+		r.Add(b, NewError("not_greater_than_field", "must be greater than "+a, map[string]any{"field": a}))
+	}
+}
+
+// EnsureFieldsEqual ensures that field b is equal to field a as determined by reflect.DeepEqual, e.g. that
+// "password_confirmation" matches "password". An error is added to b. Nothing is checked if either field already has an
+// error. Unlike EnsureFieldLessThan a nil field is compared like any other value.
+func (r *RecordWithErrors) EnsureFieldsEqual(a, b string) {
+	if r.description != nil {
+		r.description.depend(a, b, "equal")
+		return
+	}
+
+	if r.hasErrors(a) || r.hasErrors(b) {
+		return
+	}
+
+	if !reflect.DeepEqual(r.Get(a), r.Get(b)) {
+		r.Add(b, NewError("not_equal_to_field", "must match "+a, map[string]any{"field": a}))
+	}
+}
+
+// EnsureFieldsDiffer ensures that field b is not equal to field a as determined by reflect.DeepEqual, e.g. that
+// "new_password" differs from "current_password". An error is added to b. Nothing is checked if either field is nil or
+// already has an error.
+func (r *RecordWithErrors) EnsureFieldsDiffer(a, b string) {
+	if r.description != nil {
+		r.description.depend(a, b, "differ")
+		return
+	}
+
+	av, bv, ok := r.crossFieldValues(a, b)
+	if !ok {
+		return
+	}
+
+	if reflect.DeepEqual(av, bv) {
+		r.Add(b, NewError("equal_to_field", "must differ from "+a, map[string]any{"field": a}))
+	}
+}
+
+// crossFieldValues returns the values of fields a and b. ok is false if either is nil or has an error.
+func (r *RecordWithErrors) crossFieldValues(a, b string) (av, bv any, ok bool) {
+	if r.hasErrors(a) || r.hasErrors(b) {
+		return nil, nil, false
+	}
+
+	av, bv = r.Get(a), r.Get(b)
+	if av == nil || bv == nil {
+		return nil, nil, false
+	}
+
+	return av, bv, true
+}
+
+// hasErrors reports whether any error has been added to field or a path within it.
+func (r *RecordWithErrors) hasErrors(field string) bool {
+	for _, fe := range r.fieldErrors {
+		if len(fe.Path) > 0 && fe.Path[0] == field {
+			return true
+		}
+	}
+	return false
+}
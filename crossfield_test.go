@@ -0,0 +1,79 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureFieldLessThan(t *testing.T) {
+	tests := []struct {
+		record ensure.GetterSetterMap
+		errMsg string
+	}{
+		{ensure.GetterSetterMap{"start": "2024-01-01", "end": "2024-01-02"}, ""},
+		{ensure.GetterSetterMap{"start": "2024-01-02", "end": "2024-01-02"}, "end: must be greater than start"},
+		{ensure.GetterSetterMap{"start": "2024-01-03", "end": "2024-01-02"}, "end: must be greater than start"},
+		{ensure.GetterSetterMap{"start": "2024-01-03", "end": nil}, ""},
+		{ensure.GetterSetterMap{"start": "bogus", "end": "2024-01-02"}, "start: not a valid date"},
+	}
+
+	for i, tt := range tests {
+		errs := ensure.Record(tt.record, func(r *ensure.RecordWithErrors) {
+			r.Ensure("start", ensure.Date())
+			r.Ensure("end", ensure.Date())
+			r.EnsureFieldLessThan("start", "end")
+		})
+		if tt.errMsg == "" {
+			assert.NoErrorf(t, errs, "%d", i)
+		} else {
+			var recordErrs *ensure.RecordErrors
+			require.ErrorAsf(t, errs, &recordErrs, "%d", i)
+			require.Lenf(t, recordErrs.FieldErrors(), 1, "%d", i)
+			assert.Equalf(t, tt.errMsg, recordErrs.FieldErrors()[0].Error(), "%d", i)
+		}
+	}
+
+	errs := ensure.Record(ensure.GetterSetterMap{"min": "10", "max": 5}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("min", ensure.Decimal())
+		r.EnsureFieldLessThan("min", "max")
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.EqualError(t, recordErrs.FieldErrors()[0], "max: must be greater than min")
+
+	errs = ensure.Record(ensure.GetterSetterMap{"min": 1, "max": 5}, func(r *ensure.RecordWithErrors) {
+		r.EnsureFieldLessThan("min", "max")
+	})
+	assert.NoError(t, errs)
+}
+
+func TestEnsureFieldsEqualAndDiffer(t *testing.T) {
+	tests := []struct {
+		record ensure.GetterSetterMap
+		errMsg string
+	}{
+		{ensure.GetterSetterMap{"current": "old", "password": "secret", "confirmation": "secret"}, ""},
+		{ensure.GetterSetterMap{"current": "old", "password": "secret", "confirmation": "secrte"}, "confirmation: must match password"},
+		{ensure.GetterSetterMap{"current": "old", "password": "secret", "confirmation": nil}, "confirmation: must match password"},
+		{ensure.GetterSetterMap{"current": "secret", "password": "secret", "confirmation": "secret"}, "password: must differ from current"},
+	}
+
+	for i, tt := range tests {
+		errs := ensure.Record(tt.record, func(r *ensure.RecordWithErrors) {
+			r.EnsureFieldsEqual("password", "confirmation")
+			r.EnsureFieldsDiffer("current", "password")
+		})
+		if tt.errMsg == "" {
+			assert.NoErrorf(t, errs, "%d", i)
+		} else {
+			var recordErrs *ensure.RecordErrors
+			require.ErrorAsf(t, errs, &recordErrs, "%d", i)
+			require.Lenf(t, recordErrs.FieldErrors(), 1, "%d", i)
+			assert.Equalf(t, tt.errMsg, recordErrs.FieldErrors()[0].Error(), "%d", i)
+		}
+	}
+}
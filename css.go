@@ -0,0 +1,124 @@
+package ensure
+
+import (
+	"regexp"
+	"strings"
+)
+
+// cssNamedColors is the set of named colors defined by CSS Color Module Level 4 plus the special keywords transparent
+// and currentcolor.
+var cssNamedColors = map[string]struct{}{
+	"aliceblue": {}, "antiquewhite": {}, "aqua": {}, "aquamarine": {}, "azure": {}, "beige": {}, "bisque": {},
+	"black": {}, "blanchedalmond": {}, "blue": {}, "blueviolet": {}, "brown": {}, "burlywood": {}, "cadetblue": {},
+	"chartreuse": {}, "chocolate": {}, "coral": {}, "cornflowerblue": {}, "cornsilk": {}, "crimson": {}, "cyan": {},
+	"darkblue": {}, "darkcyan": {}, "darkgoldenrod": {}, "darkgray": {}, "darkgreen": {}, "darkgrey": {},
+	"darkkhaki": {}, "darkmagenta": {}, "darkolivegreen": {}, "darkorange": {}, "darkorchid": {}, "darkred": {},
+	"darksalmon": {}, "darkseagreen": {}, "darkslateblue": {}, "darkslategray": {}, "darkslategrey": {},
+	"darkturquoise": {}, "darkviolet": {}, "deeppink": {}, "deepskyblue": {}, "dimgray": {}, "dimgrey": {},
+	"dodgerblue": {}, "firebrick": {}, "floralwhite": {}, "forestgreen": {}, "fuchsia": {}, "gainsboro": {},
+	"ghostwhite": {}, "gold": {}, "goldenrod": {}, "gray": {}, "green": {}, "greenyellow": {}, "grey": {},
+	"honeydew": {}, "hotpink": {}, "indianred": {}, "indigo": {}, "ivory": {}, "khaki": {}, "lavender": {},
+	"lavenderblush": {}, "lawngreen": {}, "lemonchiffon": {}, "lightblue": {}, "lightcoral": {}, "lightcyan": {},
+	"lightgoldenrodyellow": {}, "lightgray": {}, "lightgreen": {}, "lightgrey": {}, "lightpink": {},
+	"lightsalmon": {}, "lightseagreen": {}, "lightskyblue": {}, "lightslategray": {}, "lightslategrey": {},
+	"lightsteelblue": {}, "lightyellow": {}, "lime": {}, "limegreen": {}, "linen": {}, "magenta": {}, "maroon": {},
+	"mediumaquamarine": {}, "mediumblue": {}, "mediumorchid": {}, "mediumpurple": {}, "mediumseagreen": {},
+	"mediumslateblue": {}, "mediumspringgreen": {}, "mediumturquoise": {}, "mediumvioletred": {},
+	"midnightblue": {}, "mintcream": {}, "mistyrose": {}, "moccasin": {}, "navajowhite": {}, "navy": {},
+	"oldlace": {}, "olive": {}, "olivedrab": {}, "orange": {}, "orangered": {}, "orchid": {}, "palegoldenrod": {},
+	"palegreen": {}, "paleturquoise": {}, "palevioletred": {}, "papayawhip": {}, "peachpuff": {}, "peru": {},
+	"pink": {}, "plum": {}, "powderblue": {}, "purple": {}, "rebeccapurple": {}, "red": {}, "rosybrown": {},
+	"royalblue": {}, "saddlebrown": {}, "salmon": {}, "sandybrown": {}, "seagreen": {}, "seashell": {}, "sienna": {},
+	"silver": {}, "skyblue": {}, "slateblue": {}, "slategray": {}, "slategrey": {}, "snow": {}, "springgreen": {},
+	"steelblue": {}, "tan": {}, "teal": {}, "thistle": {}, "tomato": {}, "turquoise": {}, "violet": {}, "wheat": {},
+	"white": {}, "whitesmoke": {}, "yellow": {}, "yellowgreen": {},
+	"transparent": {}, "currentcolor": {},
+}
+
+const cssNumber = `[+-]?(?:\d+\.?\d*|\.\d+)(?:e[+-]?\d+)?`
+
+var cssColorRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^#(?:[0-9a-f]{3,4}|[0-9a-f]{6}|[0-9a-f]{8})$`),
+	regexp.MustCompile(`(?i)^rgba?\(\s*` + cssNumber + `%?\s*,\s*` + cssNumber + `%?\s*,\s*` + cssNumber + `%?\s*(?:,\s*` + cssNumber + `%?\s*)?\)$`),
+	regexp.MustCompile(`(?i)^rgba?\(\s*` + cssNumber + `%?\s+` + cssNumber + `%?\s+` + cssNumber + `%?\s*(?:/\s*` + cssNumber + `%?\s*)?\)$`),
+	regexp.MustCompile(`(?i)^hsla?\(\s*` + cssNumber + `(?:deg|rad|grad|turn)?\s*,\s*` + cssNumber + `%\s*,\s*` + cssNumber + `%\s*(?:,\s*` + cssNumber + `%?\s*)?\)$`),
+	regexp.MustCompile(`(?i)^hsla?\(\s*` + cssNumber + `(?:deg|rad|grad|turn)?\s+` + cssNumber + `%\s+` + cssNumber + `%\s*(?:/\s*` + cssNumber + `%?\s*)?\)$`),
+}
+
+// CSSColor returns a Ensurer that validates value is a CSS color: a hex color (#rgb, #rgba, #rrggbb, or #rrggbbaa), an
+// rgb(), rgba(), hsl(), or hsla() function, or a named color. Space is trimmed from both sides of the string. If value
+// is nil then nil is returned. If value is not a string then an error is returned.
+func CSSColor() Ensurer {
+	return describe("csscolor", nil, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		s = strings.TrimSpace(s)
+		if _, ok := cssNamedColors[strings.ToLower(s)]; ok {
+			return s, nil
+		}
+		for _, re := range cssColorRegexps {
+			if re.MatchString(s) {
+				return s, nil
+			}
+		}
+
+		return nil, NewError("invalid_css_color", "not a valid CSS color", nil)
+	}))
+}
+
+var cssLengthRegexp = regexp.MustCompile(`(?i)^(` + cssNumber + `)([a-z]+|%)?$`)
+
+var cssLengthUnits = []string{
+	"px", "em", "rem", "ex", "ch", "vw", "vh", "vmin", "vmax", "cm", "mm", "q", "in", "pt", "pc", "%",
+}
+
+// CSSLength returns a Ensurer that validates value is a CSS length such as "12px", "1.5rem", or "50%". A unitless
+// zero is allowed. If units are given then only those units are accepted. Space is trimmed from both sides of the
+// string. If value is nil then nil is returned. If value is not a string then an error is returned.
+func CSSLength(units ...string) Ensurer {
+	if len(units) == 0 {
+		units = cssLengthUnits
+	}
+	allowed := make(map[string]struct{}, len(units))
+	for _, unit := range units {
+		allowed[strings.ToLower(unit)] = struct{}{}
+	}
+
+	return describe("csslength", map[string]any{"units": units}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		s = strings.TrimSpace(s)
+		match := cssLengthRegexp.FindStringSubmatch(s)
+		if match == nil {
+			return nil, NewError("invalid_css_length", "not a valid CSS length", nil)
+		}
+
+		number, unit := match[1], strings.ToLower(match[2])
+		if unit == "" {
+			if strings.Trim(number, "+-0.") != "" {
+				return nil, NewError("invalid_css_length", "not a valid CSS length", nil)
+			}
+			return s, nil
+		}
+
+		if _, ok := allowed[unit]; !ok {
+			return nil, NewError("invalid_css_length", "not a valid CSS length", map[string]any{"units": units})
+		}
+
+		return s, nil
+	}))
+}
@@ -0,0 +1,67 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSSColor(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"#fff", "#fff", true},
+		{"#FFFFFF80", "#FFFFFF80", true},
+		{" rebeccapurple ", "rebeccapurple", true},
+		{"Transparent", "Transparent", true},
+		{"rgb(255, 0, 0)", "rgb(255, 0, 0)", true},
+		{"rgba(100%, 0%, 0%, 0.5)", "rgba(100%, 0%, 0%, 0.5)", true},
+		{"rgb(255 0 0 / 50%)", "rgb(255 0 0 / 50%)", true},
+		{"hsl(120deg, 100%, 50%)", "hsl(120deg, 100%, 50%)", true},
+		{"hsl(120 100% 50% / .5)", "hsl(120 100% 50% / .5)", true},
+		{"#ffff0", nil, false},
+		{"rgb(255, 0)", nil, false},
+		{"hsl(120, 100, 50)", nil, false},
+		{"notacolor", nil, false},
+		{"red; background: url(x)", nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.CSSColor().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
+
+func TestCSSLength(t *testing.T) {
+	tests := []struct {
+		value    any
+		units    []string
+		expected any
+		success  bool
+	}{
+		{"12px", nil, "12px", true},
+		{" 1.5rem ", nil, "1.5rem", true},
+		{"-.5em", nil, "-.5em", true},
+		{"50%", nil, "50%", true},
+		{"0", nil, "0", true},
+		{"12", nil, nil, false},
+		{"12 px", nil, nil, false},
+		{"12furlongs", nil, nil, false},
+		{"12em", []string{"px"}, nil, false},
+		{"12PX", []string{"px"}, "12PX", true},
+		{42, nil, nil, false},
+		{nil, nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.CSSLength(tt.units...).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
@@ -0,0 +1,182 @@
+package ensure
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CSVEnsurer ensures each row of CSV input as a record keyed by column header. It is returned by NewCSVEnsurer.
+type CSVEnsurer struct {
+	headers []string
+	re      *RecordEnsurer
+
+	checkpointEvery int
+	checkpointFn    func(cp CSVCheckpoint) error
+}
+
+// CSVRow is a row of CSV input that has been ensured.
+type CSVRow struct {
+	// Line is the line number of the start of the row. The first line is 1.
+	Line int
+
+	// Fields are the original values of the row.
+	Fields []string
+
+	// Record is the ensured record. The value of each column is a string before it is ensured. It is nil if Err is not
+	// nil.
+	Record map[string]any
+
+	// Err is a *RecordErrors if the row failed to be ensured or an error if the row has the wrong number of fields.
+	Err error
+}
+
+// CSVCheckpoint is the progress of a CSVEnsurer through its input. It is passed to the function given to
+// CSVEnsurer.WithCheckpoints and can be given to CSVEnsurer.Resume to continue after the last processed row.
+type CSVCheckpoint struct {
+	// Rows is the number of rows processed. Valid and Invalid are the number of those rows that were and were not
+	// ensured. They include the rows processed before a resume.
+	Rows    int
+	Valid   int
+	Invalid int
+
+	// Line is the line number of the start of the last processed row.
+	Line int
+
+	// Offset is the byte offset of the input after the last processed row.
+	Offset int64
+}
+
+// NewCSVEnsurer returns a CSVEnsurer that ensures each row with fn. headers are the names of the columns. If headers is
+// nil then the first row of the input is used as the headers. Space and a leading byte order mark are trimmed from
+// headers read from the input.
+func NewCSVEnsurer(headers []string, fn EnsureRecordFunc) *CSVEnsurer {
+	return &CSVEnsurer{headers: headers, re: NewRecordEnsurer(fn)}
+}
+
+// Ensure reads CSV from r and calls fn with each row in order. Rows that fail are passed to fn with Err set so all
+// problems in a file can be reported at once; a BatchSummary and a Quarantine can be used to collect them. If fn
+// returns an error Ensure stops and returns it. If the input is not valid CSV an error is returned.
+func (ce *CSVEnsurer) Ensure(r io.Reader, fn func(row *CSVRow) error) error {
+	return ce.ensure(r, CSVCheckpoint{}, fn)
+}
+
+// WithCheckpoints returns a copy of ce that calls checkpoint after every n rows and after the last row so a long
+// running import can record its progress. A row is processed once the function passed to Ensure returns for it, so
+// checkpoint should persist the CSVCheckpoint only after the rows before it are durable. If checkpoint returns an
+// error Ensure stops and returns it. If n is less than 1 checkpoint is only called after the last row.
+func (ce *CSVEnsurer) WithCheckpoints(n int, checkpoint func(cp CSVCheckpoint) error) *CSVEnsurer {
+	newCE := *ce
+	newCE.checkpointEvery = n
+	newCE.checkpointFn = checkpoint
+	return &newCE
+}
+
+// Resume is like Ensure except that the rows up to cp.Offset are skipped without being ensured or passed to fn. r must
+// be the same input from the start. The rows are still read so line numbers stay correct and so r need not be
+// seekable. The counts of cp are continued by later checkpoints. An error is returned if the input ends before
+// cp.Offset or cp.Offset is not the end of a row.
+func (ce *CSVEnsurer) Resume(r io.Reader, cp CSVCheckpoint, fn func(row *CSVRow) error) error {
+	return ce.ensure(r, cp, fn)
+}
+
+func (ce *CSVEnsurer) ensure(r io.Reader, cp CSVCheckpoint, fn func(row *CSVRow) error) error {
+	reader := csv.NewReader(r)
+
+	headers := ce.headers
+	if headers == nil {
+		fields, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				if cp.Offset > 0 {
+					return fmt.Errorf("input ends before checkpoint offset %d", cp.Offset)
+				}
+				return nil
+			}
+			return err
+		}
+
+		headers = make([]string, len(fields))
+		for i, f := range fields {
+			if i == 0 {
+				f = strings.TrimPrefix(f, "\ufeff")
+			}
+			headers[i] = strings.TrimSpace(f)
+		}
+	}
+	reader.FieldsPerRecord = len(headers)
+
+	for reader.InputOffset() < cp.Offset {
+		_, err := reader.Read()
+		if err == io.EOF {
+			return fmt.Errorf("input ends before checkpoint offset %d", cp.Offset)
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !(errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount)) {
+			return err
+		}
+	}
+	if cp.Offset > 0 && reader.InputOffset() != cp.Offset {
+		return fmt.Errorf("checkpoint offset %d is not the end of a row", cp.Offset)
+	}
+
+	sinceCheckpoint := 0
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			if sinceCheckpoint > 0 && ce.checkpointFn != nil {
+				return ce.checkpointFn(cp)
+			}
+			return nil
+		}
+
+		row := &CSVRow{Fields: fields}
+		if fields != nil {
+			row.Line, _ = reader.FieldPos(0)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			row.Line = parseErr.StartLine
+			row.Err = NewError("wrong_field_count", fmt.Sprintf("has %d fields instead of %d", len(fields), len(headers)),
+				map[string]any{"expected": len(headers), "actual": len(fields)})
+		} else if err != nil {
+			return err
+		} else {
+			record := make(map[string]any, len(headers))
+			for i, h := range headers {
+				record[h] = fields[i]
+			}
+
+			_, row.Err = ce.re.Ensure(record)
+			if row.Err == nil {
+				row.Record = record
+			}
+		}
+
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+
+		cp.Rows++
+		if row.Err == nil {
+			cp.Valid++
+		} else {
+			cp.Invalid++
+		}
+		cp.Line = row.Line
+		cp.Offset = reader.InputOffset()
+
+		sinceCheckpoint++
+		if ce.checkpointFn != nil && ce.checkpointEvery > 0 && sinceCheckpoint == ce.checkpointEvery {
+			sinceCheckpoint = 0
+			err = ce.checkpointFn(cp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ensure_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVEnsurer(t *testing.T) {
+	ce := ensure.NewCSVEnsurer(nil, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+	})
+
+	input := "\ufeffname, age\nJack,42\n,abc\n\"Jill\nSmith\",30\nJoe\nAnn,7\n"
+
+	var rows []*ensure.CSVRow
+	err := ce.Ensure(strings.NewReader(input), func(row *ensure.CSVRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, map[string]any{"name": "Jack", "age": int32(42)}, rows[0].Record)
+	assert.Equal(t, []string{"Jack", "42"}, rows[0].Fields)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Nil(t, rows[1].Record)
+	var etErr *errortree.Node
+	require.ErrorAs(t, rows[1].Err, &etErr)
+	assert.Len(t, etErr.Get([]any{"name"}), 1)
+	assert.Len(t, etErr.Get([]any{"age"}), 1)
+
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Equal(t, "Jill Smith", rows[2].Record["name"])
+
+	assert.Equal(t, 6, rows[3].Line)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, rows[3].Err, &ensureErr)
+	assert.Equal(t, "wrong_field_count", ensureErr.Code)
+
+	assert.Equal(t, 7, rows[4].Line)
+	assert.NoError(t, rows[4].Err)
+
+	ce = ensure.NewCSVEnsurer([]string{"name", "age"}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("age", ensure.Int32())
+	})
+	stop := errors.New("stop")
+	var lines []int
+	err = ce.Ensure(strings.NewReader("Jack,42\nJill,30\n"), func(row *ensure.CSVRow) error {
+		lines = append(lines, row.Line)
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []int{1}, lines)
+
+	err = ce.Ensure(strings.NewReader("Jack,\"42\n"), func(row *ensure.CSVRow) error { return nil })
+	assert.Error(t, err)
+
+	err = ce.Ensure(strings.NewReader(""), func(row *ensure.CSVRow) error { return nil })
+	assert.NoError(t, err)
+}
+
+func TestCSVEnsurerCheckpoints(t *testing.T) {
+	ce := ensure.NewCSVEnsurer(nil, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+	})
+
+	input := "name,age\nJack,42\n,abc\n\"Jill\nSmith\",30\nJoe\nAnn,7\n"
+
+	var checkpoints []ensure.CSVCheckpoint
+	err := ce.WithCheckpoints(2, func(cp ensure.CSVCheckpoint) error {
+		checkpoints = append(checkpoints, cp)
+		return nil
+	}).Ensure(strings.NewReader(input), func(row *ensure.CSVRow) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, []ensure.CSVCheckpoint{
+		{Rows: 2, Valid: 1, Invalid: 1, Line: 3, Offset: int64(strings.Index(input, "\"Jill"))},
+		{Rows: 4, Valid: 2, Invalid: 2, Line: 6, Offset: int64(strings.Index(input, "Ann"))},
+		{Rows: 5, Valid: 3, Invalid: 2, Line: 7, Offset: int64(len(input))},
+	}, checkpoints)
+
+	var lines []int
+	var last ensure.CSVCheckpoint
+	err = ce.WithCheckpoints(0, func(cp ensure.CSVCheckpoint) error {
+		last = cp
+		return nil
+	}).Resume(strings.NewReader(input), checkpoints[0], func(row *ensure.CSVRow) error {
+		lines = append(lines, row.Line)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 6, 7}, lines)
+	assert.Equal(t, checkpoints[2], last)
+
+	err = ce.Resume(strings.NewReader(input), checkpoints[2], func(row *ensure.CSVRow) error {
+		t.Error("row after the end")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	stop := errors.New("stop")
+	err = ce.WithCheckpoints(1, func(cp ensure.CSVCheckpoint) error {
+		return stop
+	}).Ensure(strings.NewReader(input), func(row *ensure.CSVRow) error { return nil })
+	assert.Equal(t, stop, err)
+
+	for i, cp := range []ensure.CSVCheckpoint{
+		{Offset: 3},
+		{Offset: int64(len(input)) + 1},
+	} {
+		err = ce.Resume(strings.NewReader(input), cp, func(row *ensure.CSVRow) error { return nil })
+		assert.Errorf(t, err, "%d", i)
+	}
+
+	err = ce.Resume(strings.NewReader(""), ensure.CSVCheckpoint{Offset: 10}, func(row *ensure.CSVRow) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package ensure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// CursorCodec encodes and decodes opaque pagination cursors. A cursor is the JSON encoding of a value and the time it
+// was issued, encoded with unpadded URL-safe base64 and, if Key is set, authenticated with HMAC-SHA256.
+type CursorCodec struct {
+	// Key is the HMAC key used to authenticate cursors. If Key is nil then cursors are not authenticated.
+	Key []byte
+
+	// MaxAge is the maximum age of a cursor. If MaxAge is 0 then cursors do not expire.
+	MaxAge time.Duration
+}
+
+type cursorPayload struct {
+	Value    json.RawMessage `json:"v"`
+	IssuedAt int64           `json:"t"`
+}
+
+// Encode returns a cursor for v.
+func (c *CursorCodec) Encode(v any) (string, error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(cursorPayload{Value: value, IssuedAt: time.Now().UnixNano()})
+	if err != nil {
+		return "", err
+	}
+
+	cursor := base64.RawURLEncoding.EncodeToString(payload)
+	if c.Key != nil {
+		cursor += "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+	}
+
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.Key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// decode decodes cursor into v.
+func (c *CursorCodec) decode(cursor string, v any) error {
+	encodedPayload, encodedSignature, signed := strings.Cut(cursor, ".")
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return NewError("invalid_cursor", "not a valid cursor", nil)
+	}
+
+	if c.Key != nil {
+		signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+		if !signed || err != nil || !hmac.Equal(signature, c.sign(payload)) {
+			return NewError("cursor_tampered", "cursor has been tampered with", nil)
+		}
+	} else if signed {
+		return NewError("invalid_cursor", "not a valid cursor", nil)
+	}
+
+	var cp cursorPayload
+	err = json.Unmarshal(payload, &cp)
+	if err != nil {
+		return NewError("invalid_cursor", "not a valid cursor", nil)
+	}
+
+	if c.MaxAge != 0 && time.Since(time.Unix(0, cp.IssuedAt)) > c.MaxAge {
+		return NewError("cursor_expired", "cursor has expired", nil)
+	}
+
+	err = json.Unmarshal(cp.Value, v)
+	if err != nil {
+		return NewError("invalid_cursor", "not a valid cursor", nil)
+	}
+
+	return nil
+}
+
+// Cursor returns a Ensurer that decodes a cursor created by codec.Encode into a T. Cursors that are malformed, fail
+// authentication, or have expired are rejected with the error codes "invalid_cursor", "cursor_tampered", and
+// "cursor_expired" respectively. If value is nil or a blank string nil is returned. If value is not a string then an
+// error is returned.
+func Cursor[T any](codec *CursorCodec) Ensurer {
+	return describe("cursor", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		var t T
+		err := codec.decode(s, &t)
+		if err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	}))
+}
@@ -0,0 +1,71 @@
+package ensure_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pageCursor struct {
+	LastID int64  `json:"last_id"`
+	Sort   string `json:"sort"`
+}
+
+func TestCursor(t *testing.T) {
+	codec := &ensure.CursorCodec{Key: []byte("secret")}
+	cursor, err := codec.Encode(pageCursor{LastID: 42, Sort: "name"})
+	require.NoError(t, err)
+
+	unsignedCodec := &ensure.CursorCodec{}
+	unsignedCursor, err := unsignedCodec.Encode(pageCursor{LastID: 7})
+	require.NoError(t, err)
+
+	otherKeyCursor, err := (&ensure.CursorCodec{Key: []byte("other")}).Encode(pageCursor{LastID: 42})
+	require.NoError(t, err)
+
+	tests := []struct {
+		codec    *ensure.CursorCodec
+		value    any
+		expected any
+		code     string
+	}{
+		{codec, cursor, pageCursor{LastID: 42, Sort: "name"}, ""},
+		{unsignedCodec, unsignedCursor, pageCursor{LastID: 7}, ""},
+		{codec, otherKeyCursor, nil, "cursor_tampered"},
+		{codec, unsignedCursor, nil, "cursor_tampered"},
+		{codec, "!!!", nil, "invalid_cursor"},
+		{codec, "x" + cursor, nil, "cursor_tampered"},
+		{unsignedCodec, cursor, nil, "invalid_cursor"},
+		{codec, 42, nil, "not_a_string"},
+		{codec, nil, nil, ""},
+		{codec, " ", nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Cursor[pageCursor](tt.codec).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		if tt.code == "" {
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			var ensureErr *ensure.Error
+			require.ErrorAsf(t, err, &ensureErr, "%d", i)
+			assert.Equalf(t, tt.code, ensureErr.Code, "%d", i)
+		}
+	}
+}
+
+func TestCursorExpired(t *testing.T) {
+	codec := &ensure.CursorCodec{Key: []byte("secret"), MaxAge: time.Millisecond}
+	cursor, err := codec.Encode(pageCursor{LastID: 42})
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = ensure.Cursor[pageCursor](codec).Ensure(cursor)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "cursor_expired", ensureErr.Code)
+}
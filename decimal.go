@@ -0,0 +1,165 @@
+package ensure
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DecimalPrecisionEnsurer is a Ensurer that checks a number fits a SQL NUMERIC(precision, scale) column. It is returned
+// by DecimalPrecision.
+type DecimalPrecisionEnsurer struct {
+	precision int32
+	scale     int32
+	round     bool
+}
+
+// DecimalPrecision returns a Ensurer that fails if value has more than precision total digits or more than scale
+// digits after the decimal point, matching a SQL NUMERIC(precision, scale) column. Trailing zeros after the decimal
+// point are not counted. precision must be positive and scale must be between 0 and precision or DecimalPrecision
+// panics. value must be convertable to a decimal number. nil is returned unmodified.
+func DecimalPrecision(precision, scale int) *DecimalPrecisionEnsurer {
+	if precision <= 0 {
+		panic(fmt.Errorf("precision must be positive: %d", precision))
+	}
+	if scale < 0 || scale > precision {
+		panic(fmt.Errorf("scale must be between 0 and %d: %d", precision, scale))
+	}
+
+	return &DecimalPrecisionEnsurer{precision: int32(precision), scale: int32(scale)}
+}
+
+// Round returns a copy of dpe that rounds value to scale digits after the decimal point instead of failing, the same as
+// a database would on insert. The result is a decimal.Decimal. It still fails if the rounded value has too many digits
+// before the decimal point.
+func (dpe *DecimalPrecisionEnsurer) Round() *DecimalPrecisionEnsurer {
+	newDPE := *dpe
+	newDPE.round = true
+	return &newDPE
+}
+
+func (dpe *DecimalPrecisionEnsurer) Describe() Rule {
+	params := map[string]any{"precision": int(dpe.precision), "scale": int(dpe.scale), "round": dpe.round}
+	return Rule{Kind: "decimalprecision", Params: params}
+}
+
+func (dpe *DecimalPrecisionEnsurer) Ensure(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	n, ok := tryDecimal(value)
+	if !ok {
+		return nil, NewError("not_a_number", "not a number", nil)
+	}
+
+	params := map[string]any{"precision": int(dpe.precision), "scale": int(dpe.scale)}
+
+	rounded := n.Round(dpe.scale)
+	if !dpe.round && !rounded.Equal(n) {
+		return nil, NewError("too_many_decimal_places", "too many digits after the decimal point", params)
+	}
+
+	integerPart := rounded.Truncate(0).Abs()
+	if !integerPart.IsZero() && int32(len(integerPart.String())) > dpe.precision-dpe.scale {
+		return nil, NewError("too_many_digits", "too many digits", params)
+	}
+
+	if dpe.round {
+		return rounded, nil
+	}
+
+	return value, nil
+}
+
+// Round returns a Ensurer that rounds value to places digits after the decimal point, rounding halves away from zero.
+// places may be negative to round to the left of the decimal point. value must be convertable to a decimal number. The
+// result has the same type as value if it is a decimal.Decimal, float, or integer, otherwise it is a decimal.Decimal.
+// nil is returned unmodified.
+func Round(places int32) Ensurer {
+	return describe("round", map[string]any{"places": places},
+		decimalNormalizer(func(n decimal.Decimal) decimal.Decimal { return n.Round(places) }))
+}
+
+// Truncate returns a Ensurer that truncates value to places digits after the decimal point. places must not be
+// negative or Truncate panics. Otherwise it behaves like Round.
+func Truncate(places int32) Ensurer {
+	if places < 0 {
+		panic(fmt.Errorf("places must not be negative: %d", places))
+	}
+
+	return describe("truncate", map[string]any{"places": places},
+		decimalNormalizer(func(n decimal.Decimal) decimal.Decimal { return n.Truncate(places) }))
+}
+
+// Floor returns a Ensurer that rounds value down to the nearest integer. Otherwise it behaves like Round.
+func Floor() Ensurer {
+	return describe("floor", nil, decimalNormalizer(decimal.Decimal.Floor))
+}
+
+// Ceil returns a Ensurer that rounds value up to the nearest integer. Otherwise it behaves like Round.
+func Ceil() Ensurer {
+	return describe("ceil", nil, decimalNormalizer(decimal.Decimal.Ceil))
+}
+
+// decimalNormalizer returns a Ensurer that applies fn to value as a decimal.Decimal and converts the result back to
+// the type of value.
+func decimalNormalizer(fn func(decimal.Decimal) decimal.Decimal) Ensurer {
+	return EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		n, ok := tryDecimal(value)
+		if !ok {
+			return nil, NewError("not_a_number", "not a number", nil)
+		}
+		n = fn(n)
+
+		switch value.(type) {
+		case float64:
+			return n.InexactFloat64(), nil
+		case float32:
+			return float32(n.InexactFloat64()), nil
+		case int64, int32, int16, int8, int, uint64, uint32, uint16, uint8, uint:
+			result, err := convertNumberLike(n, value)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+
+		return n, nil
+	})
+}
+
+// convertNumberLike converts n, which must be an integer, to the integer type of like.
+func convertNumberLike(n decimal.Decimal, like any) (any, error) {
+	s := n.String()
+
+	switch like.(type) {
+	case int64:
+		return convertInt64(s)
+	case int32:
+		return convertInt32(s)
+	case int16:
+		return convertInt16(s)
+	case int8:
+		return convertInt8(s)
+	case int:
+		return convertInt(s)
+	case uint64:
+		return convertUint64(s)
+	case uint32:
+		return convertUint32(s)
+	case uint16:
+		return convertUint16(s)
+	case uint8:
+		return convertUint8(s)
+	case uint:
+		n, err := convertUint64(s)
+		return uint(n), err
+	}
+
+	return n, nil
+}
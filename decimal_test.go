@@ -0,0 +1,90 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalPrecision(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.DecimalPrecision(5, 2), decimal.RequireFromString("123.45"), decimal.RequireFromString("123.45"), true},
+		{ensure.DecimalPrecision(5, 2), decimal.RequireFromString("-123.45"), decimal.RequireFromString("-123.45"), true},
+		{ensure.DecimalPrecision(5, 2), decimal.RequireFromString("1.50"), decimal.RequireFromString("1.50"), true},
+		{ensure.DecimalPrecision(5, 2), decimal.RequireFromString("0.001"), nil, false},
+		{ensure.DecimalPrecision(5, 2), decimal.RequireFromString("1234.5"), nil, false},
+		{ensure.DecimalPrecision(5, 2), "99.9", "99.9", true},
+		{ensure.DecimalPrecision(5, 2), int64(999), int64(999), true},
+		{ensure.DecimalPrecision(5, 0), int64(100000), nil, false},
+		{ensure.DecimalPrecision(2, 2), decimal.RequireFromString("0.99"), decimal.RequireFromString("0.99"), true},
+		{ensure.DecimalPrecision(2, 2), decimal.RequireFromString("1"), nil, false},
+		{ensure.DecimalPrecision(5, 2).Round(), decimal.RequireFromString("1.005"), decimal.RequireFromString("1.01"), true},
+		{ensure.DecimalPrecision(5, 2).Round(), "12.3456", decimal.RequireFromString("12.35"), true},
+		{ensure.DecimalPrecision(3, 1).Round(), decimal.RequireFromString("99.96"), nil, false},
+		{ensure.DecimalPrecision(5, 2), "abc", nil, false},
+		{ensure.DecimalPrecision(5, 2), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if expected, ok := tt.expected.(decimal.Decimal); ok {
+			if assert.IsTypef(t, decimal.Decimal{}, value, "%d", i) {
+				assert.Truef(t, expected.Equal(value.(decimal.Decimal)), "%d: %v", i, value)
+			}
+		} else {
+			assert.Equalf(t, tt.expected, value, "%d", i)
+		}
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+
+	assert.Panics(t, func() { ensure.DecimalPrecision(0, 0) })
+	assert.Panics(t, func() { ensure.DecimalPrecision(2, 3) })
+}
+
+func TestRoundingEnsurers(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Round(2), decimal.RequireFromString("1.005"), decimal.RequireFromString("1.01"), true},
+		{ensure.Round(2), decimal.RequireFromString("-1.005"), decimal.RequireFromString("-1.01"), true},
+		{ensure.Round(1), float64(2.25), float64(2.3), true},
+		{ensure.Round(0), float32(2.5), float32(3), true},
+		{ensure.Round(-1), int64(15), int64(20), true},
+		{ensure.Round(-1), int32(-15), int32(-20), true},
+		{ensure.Round(-1), int8(125), nil, false},
+		{ensure.Round(2), int(7), int(7), true},
+		{ensure.Round(2), "3.14159", decimal.RequireFromString("3.14"), true},
+		{ensure.Truncate(1), decimal.RequireFromString("1.99"), decimal.RequireFromString("1.9"), true},
+		{ensure.Truncate(0), float64(-1.99), float64(-1), true},
+		{ensure.Floor(), decimal.RequireFromString("-1.1"), decimal.RequireFromString("-2"), true},
+		{ensure.Floor(), float64(1.9), float64(1), true},
+		{ensure.Ceil(), decimal.RequireFromString("1.1"), decimal.RequireFromString("2"), true},
+		{ensure.Ceil(), uint16(4), uint16(4), true},
+		{ensure.Round(2), "abc", nil, false},
+		{ensure.Round(2), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if expected, ok := tt.expected.(decimal.Decimal); ok {
+			if assert.IsTypef(t, decimal.Decimal{}, value, "%d", i) {
+				assert.Truef(t, expected.Equal(value.(decimal.Decimal)), "%d: %v", i, value)
+			}
+		} else {
+			assert.Equalf(t, tt.expected, value, "%d", i)
+		}
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+
+	assert.Panics(t, func() { ensure.Truncate(-1) })
+}
@@ -0,0 +1,185 @@
+package ensure
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rule describes what an ensurer does. It is returned by Describer.
+type Rule struct {
+	// Kind is the name of the ensurer such as "maxlen" or "int32". It is the same as the name of the rule in a
+	// Registry where there is one and otherwise the lowercased name of the function that returns the ensurer.
+	Kind string
+
+	// Params are the arguments of the ensurer such as {"max": 10} for MaxLen(10). Where an error of the ensurer has
+	// params of the same meaning the same keys are used. Ensurers that wrap other ensurers include them as an Ensurer
+	// ("element" for Slice, "key" and "value" for Map), an []Ensurer ("ensurers" for All, AnyOf, When, etc.), or a
+	// *RecordEnsurer ("record" for Nested). Secrets such as the key of HMAC are not included.
+	Params map[string]any
+}
+
+// Describer is implemented by ensurers that can describe themselves for schema export, documentation generation, and
+// test tooling. All of the built-in ensurers implement Describer.
+type Describer interface {
+	Describe() Rule
+}
+
+// Describe returns the Rule of e. If e does not implement Describer then Kind is "unknown" and Params has the Go type
+// of e as "type".
+func Describe(e Ensurer) Rule {
+	if d, ok := e.(Describer); ok {
+		return d.Describe()
+	}
+	return Rule{Kind: "unknown", Params: map[string]any{"type": fmt.Sprintf("%T", e)}}
+}
+
+// describedEnsurer adds a Rule to an Ensurer.
+type describedEnsurer struct {
+	Ensurer
+	rule Rule
+}
+
+// describe returns e with the Rule of kind and params.
+func describe(kind string, params map[string]any, e Ensurer) Ensurer {
+	return &describedEnsurer{Ensurer: e, rule: Rule{Kind: kind, Params: params}}
+}
+
+func (de *describedEnsurer) EnsureContext(ctx context.Context, value any) (any, error) {
+	return ensureContext(ctx, de.Ensurer, value)
+}
+
+func (de *describedEnsurer) Describe() Rule {
+	return de.rule
+}
+
+// Describe returns a Rule of kind "nested" so a RecordEnsurer used as an Ensurer is described the same as Nested.
+func (re *RecordEnsurer) Describe() Rule {
+	return Rule{Kind: "nested", Params: map[string]any{"record": re}}
+}
+
+// FieldRules is a field of a record definition and the rules of its ensurers. It is returned by RecordEnsurer.Rules.
+type FieldRules struct {
+	// Field is the name of the field in the input. For EnsureAs it is the source field.
+	Field string
+
+	// Label is the label of the field set with RecordWithErrors.Label.
+	Label string
+
+	// Metadata is the metadata of the field set with RecordWithErrors.Metadata. It is nil if there is none.
+	Metadata map[string]any
+
+	// Rules are the rules of the ensurers of the field in order. A field ensured more than once, such as in multiple
+	// phases, has the rules of every call.
+	Rules []Rule
+}
+
+// Rules returns the fields of re and the rules of their ensurers in the order the fields are first ensured. Like
+// JSONSchema, it runs each phase of re against an empty record with Ensure and EnsureAs only recording their field and
+// ensurers, so a phase must not depend on the values of the record to decide which fields to ensure. Use Describe on
+// the ensurers in the Params of a Rule to walk nested definitions.
+func (re *RecordEnsurer) Rules() []FieldRules {
+	var fields []FieldRules
+	for _, df := range re.describeRecord() {
+		fr := FieldRules{Field: df.name, Label: df.label, Metadata: df.metadata, Rules: make([]Rule, len(df.ensurers))}
+		for i, e := range df.ensurers {
+			fr.Rules[i] = Describe(e)
+		}
+		fields = append(fields, fr)
+	}
+	return fields
+}
+
+// describedField is a field of a record definition and the ensurers given for it.
+type describedField struct {
+	name     string
+	label    string
+	metadata map[string]any
+	ensurers []Ensurer
+}
+
+// describedDependency is a rule of field to that depends on field from such as EnsureFieldLessThan.
+type describedDependency struct {
+	from string
+	to   string
+	kind string
+}
+
+// recordDescription collects the fields of a record definition instead of ensuring them.
+type recordDescription struct {
+	fields       []*describedField
+	index        map[string]*describedField
+	dependencies []describedDependency
+	metadata     map[string]map[string]any
+}
+
+// addMetadata adds metadata to field.
+func (rd *recordDescription) addMetadata(field string, metadata map[string]any) {
+	if rd.metadata == nil {
+		rd.metadata = make(map[string]map[string]any)
+	}
+	if rd.metadata[field] == nil {
+		rd.metadata[field] = make(map[string]any, len(metadata))
+	}
+	for k, v := range metadata {
+		rd.metadata[field][k] = v
+	}
+}
+
+// depend records that the rule kind of field to depends on field from.
+func (rd *recordDescription) depend(from, to, kind string) {
+	rd.dependencies = append(rd.dependencies, describedDependency{from: from, to: to, kind: kind})
+}
+
+// conditional returns ensurers with their rules marked with the param "if" so the ensurers of EnsureIf are described
+// without calling its condition.
+func conditional(ensurers []Ensurer) []Ensurer {
+	marked := make([]Ensurer, len(ensurers))
+	for i, e := range ensurers {
+		r := Describe(e)
+		params := make(map[string]any, len(r.Params)+1)
+		for k, v := range r.Params {
+			params[k] = v
+		}
+		params["if"] = true
+		marked[i] = describe(r.Kind, params, e)
+	}
+	return marked
+}
+
+// add appends ensurers to field. A field ensured more than once, such as in multiple phases, is described once with all
+// of its ensurers.
+func (rd *recordDescription) add(field string, ensurers []Ensurer) {
+	if rd.index == nil {
+		rd.index = make(map[string]*describedField)
+	}
+
+	df, ok := rd.index[field]
+	if !ok {
+		df = &describedField{name: field}
+		rd.index[field] = df
+		rd.fields = append(rd.fields, df)
+	}
+	df.ensurers = append(df.ensurers, ensurers...)
+}
+
+// describeRecord returns the fields ensured by the phases of re in the order they are first ensured. Every phase is run
+// against an empty record with Ensure and EnsureAs only recording their field and ensurers.
+func (re *RecordEnsurer) describeRecord() []*describedField {
+	return re.describe().fields
+}
+
+// describe runs every phase of re against an empty record with Ensure and EnsureAs only recording their field and
+// ensurers and the cross-field rules only recording their dependencies.
+func (re *RecordEnsurer) describe() *recordDescription {
+	rwe := &RecordWithErrors{ctx: context.Background(), record: GetterSetterMap{}, description: &recordDescription{}}
+	for _, fn := range re.phases {
+		fn(rwe)
+	}
+
+	for _, df := range rwe.description.fields {
+		df.label = rwe.labels[df.name]
+		df.metadata = rwe.description.metadata[df.name]
+	}
+
+	return rwe.description
+}
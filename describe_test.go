@@ -0,0 +1,142 @@
+package ensure_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	for i, tt := range []struct {
+		ensurer ensure.Ensurer
+		rule    ensure.Rule
+	}{
+		{ensure.Int32(), ensure.Rule{Kind: "int32"}},
+		{ensure.MaxLen(10), ensure.Rule{Kind: "maxlen", Params: map[string]any{"max": 10}}},
+		{ensure.Between(1, 5), ensure.Rule{Kind: "between", Params: map[string]any{"min": 1, "max": 5}}},
+		{ensure.OneOf(1, 2), ensure.Rule{Kind: "oneof", Params: map[string]any{"allowed": []int{1, 2}}}},
+		{ensure.Alpha('-'), ensure.Rule{Kind: "alpha", Params: map[string]any{"extra": "-"}}},
+		{ensure.Date(), ensure.Rule{Kind: "date", Params: map[string]any{"formats": []string{"2006-01-02"}}}},
+		{ensure.URL("https"), ensure.Rule{Kind: "url", Params: map[string]any{"schemes": []string{"https"}}}},
+		{ensure.JSONObject().Decode(), ensure.Rule{Kind: "jsonobject"}},
+		{ensure.Match(regexp.MustCompile(`^a+$`)), ensure.Rule{Kind: "match", Params: map[string]any{"pattern": `^a+$`}}},
+		{
+			ensure.Match(regexp.MustCompile(`^a+$`)).MaxInputLen(8),
+			ensure.Rule{Kind: "match", Params: map[string]any{"pattern": `^a+$`, "max_bytes": 8}},
+		},
+		{
+			ensure.DecimalPrecision(10, 2).Round(),
+			ensure.Rule{Kind: "decimalprecision", Params: map[string]any{"precision": 10, "scale": 2, "round": true}},
+		},
+		{ensure.HMAC([]byte("secret")), ensure.Rule{Kind: "hmac"}},
+		{
+			ensure.EnsurerFunc(func(value any) (any, error) { return value, nil }),
+			ensure.Rule{Kind: "unknown", Params: map[string]any{"type": "ensure.EnsurerFunc"}},
+		},
+	} {
+		assert.Equalf(t, tt.rule, ensure.Describe(tt.ensurer), "%d", i)
+	}
+
+	element := ensure.Int64()
+	rule := ensure.Describe(ensure.Slice[int64](element))
+	assert.Equal(t, "slice", rule.Kind)
+	assert.Same(t, element, rule.Params["element"])
+
+	for i, e := range []ensure.Ensurer{
+		ensure.ArchiveSafety(10, 1024), ensure.CamelToSnake(), ensure.SnakeToCamel(), ensure.CSSColor(), ensure.CSSLength(),
+		ensure.Round(2), ensure.Truncate(2), ensure.Floor(), ensure.Ceil(), ensure.When(func(any) bool { return true }),
+		ensure.Unless(func(any) bool { return true }), ensure.UserAgent(10), ensure.MaxInputBytes(10),
+		ensure.ExcludeStrings("a"), ensure.NotContains("a"), ensure.Equal(1), ensure.NotOneOf(1), ensure.Enum("a"),
+		ensure.EnumMap(map[string]int{"a": 1}), ensure.HashSHA256Hex(), ensure.BcryptHash(4), ensure.StripHTML(),
+		ensure.EscapeHTML(), ensure.Image(10, 10), ensure.CanonicalJSON(), ensure.Locale(), ensure.AcceptLanguage(),
+		ensure.SafeMarkdown(ensure.MarkdownStrip), ensure.Mask(4, '*'), ensure.MIMEType(), ensure.ContentLength(10),
+		ensure.PhoneNumber("US"), ensure.Flag(), ensure.RRule(), ensure.SliceEach(), ensure.Sorted[int](),
+		ensure.UniqueElements(), ensure.Unique(func(context.Context, any) (bool, error) { return true, nil }),
+		ensure.Map[string, any](nil, nil), ensure.BoolNullable(), ensure.BigInt(), ensure.Decimal(), ensure.AnyOf(), ensure.All(), ensure.IfNotNil(),
+		ensure.Time(time.RFC3339), ensure.Nested(func(r *ensure.RecordWithErrors) {}),
+		ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {}),
+	} {
+		assert.NotEqualf(t, "unknown", ensure.Describe(e).Kind, "%d", i)
+	}
+}
+
+func TestDescribeRegistryRules(t *testing.T) {
+	registry := ensure.NewRegistry()
+	args := map[string][]string{
+		"minlen": {"1"}, "maxlen": {"1"}, "min": {"1"}, "max": {"1"}, "gt": {"1"}, "lt": {"1"}, "between": {"1", "2"},
+		"oneof": {"a"}, "prefix": {"a"}, "suffix": {"a"}, "contains": {"a"}, "match": {"^a$"},
+	}
+
+	for _, name := range registry.Names() {
+		e, err := registry.Lookup(name, args[name]...)
+		require.NoError(t, err, name)
+		assert.Equal(t, name, ensure.Describe(e).Kind)
+	}
+}
+
+func TestRecordEnsurerRules(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Label("name", "Full name")
+		r.Metadata("name", map[string]any{"section": "Personal", "order": 1})
+		r.Ensure("name", ensure.SingleLineString(), ensure.MaxLen(100))
+		r.EnsureAs("zipCode", "zip", ensure.String())
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("city", ensure.Require())
+		}))
+	}).Then(func(r *ensure.RecordWithErrors) {
+		r.Metadata("name", map[string]any{"order": 2})
+		r.Ensure("name", ensure.Require())
+		r.EnsureIf(func(r *ensure.RecordWithErrors) bool {
+			t.Error("condition called")
+			return false
+		}, "state", ensure.SingleLineString(), ensure.MaxLen(2))
+		r.RequireIf(ensure.FieldEquals("country", "US"), "state")
+	})
+
+	fields := re.Rules()
+	require.Len(t, fields, 4)
+
+	assert.Equal(t, ensure.FieldRules{
+		Field:    "name",
+		Label:    "Full name",
+		Metadata: map[string]any{"section": "Personal", "order": 2},
+		Rules: []ensure.Rule{
+			{Kind: "singleline"}, {Kind: "maxlen", Params: map[string]any{"max": 100}}, {Kind: "require"},
+		},
+	}, fields[0])
+	assert.Equal(t, ensure.FieldRules{Field: "zipCode", Rules: []ensure.Rule{{Kind: "string"}}}, fields[1])
+
+	assert.Equal(t, "address", fields[2].Field)
+	require.Len(t, fields[2].Rules, 1)
+	assert.Equal(t, "nested", fields[2].Rules[0].Kind)
+	nested := fields[2].Rules[0].Params["record"].(*ensure.RecordEnsurer).Rules()
+	assert.Equal(t, []ensure.FieldRules{{Field: "city", Rules: []ensure.Rule{{Kind: "require"}}}}, nested)
+
+	assert.Equal(t, ensure.FieldRules{Field: "state", Rules: []ensure.Rule{
+		{Kind: "singleline", Params: map[string]any{"if": true}},
+		{Kind: "maxlen", Params: map[string]any{"max": 2, "if": true}},
+		{Kind: "require", Params: map[string]any{"if": true}},
+	}}, fields[3])
+}
+
+func TestDescribedEnsurerContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "taken")
+
+	e := ensure.Unique(func(ctx context.Context, value any) (bool, error) {
+		return ctx.Value(key{}) != value, nil
+	})
+
+	err := ensure.RecordContext(ctx, ensure.GetterSetterMap{"name": "taken"}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.All(ensure.String()), e)
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	require.Len(t, recordErrs.ByField()["name"], 1)
+	assert.ErrorIs(t, recordErrs.ByField()["name"][0].Err, ensure.ErrTaken)
+}
@@ -0,0 +1,39 @@
+/*
+Package ensure converts and validates records of untyped input such as parsed JSON, form values, and query strings.
+
+A record is any GetterSetter. GetterSetterMap adapts a map[string]any. Record runs an EnsureRecordFunc that calls
+RecordWithErrors.Ensure for each field with a chain of Ensurer values. Each Ensurer converts or checks the value
+returned by the previous one, and the result is written back to the record. Errors are collected for every field, not
+just the first, and returned as a *RecordErrors. e.g.
+
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(100))
+		r.Ensure("age", ensure.Int32(), ensure.GreaterThanOrEqual(0))
+	})
+
+NewRecordEnsurer packages a record definition for reuse. Its options add phases (Then), concurrency
+(WithConcurrency), auditing (Audit), failure capture (CaptureFailures), and a budget for external checks (WithBudget).
+
+# Writing Ensurers
+
+Packages outside this one may provide their own ensurers. The contract for an Ensurer is:
+
+  - Return nil unmodified without an error unless the purpose of the ensurer is to reject or replace nil, as Require
+    and Flag do. This allows optional fields to be ensured with the same chain as required fields.
+  - Return failures as an *Error created with NewError. Code should be a stable snake_case identifier that describes
+    the failure, such as "too_long", and Params should hold the values needed to describe it to a user, such as
+    {"max": 10}. Reuse the codes of the built-in ensurers where the meaning is the same. Return other errors, such as
+    a failure to reach a database, unwrapped or wrapped with %w.
+  - Be idempotent: ensuring a value that was already ensured should return it unchanged.
+  - Do not panic on unexpected input. Return an error such as "not_a_string" instead.
+  - Be safe for concurrent use. Ensurers are typically stored in package variables and shared.
+  - Implement EnsurerContext if the ensurer does I/O so it receives the context given to RecordContext. Wrap it with
+    External so it is limited by the Budget of the record.
+  - Implement Describer so RecordEnsurer.Rules and tools built on it can report what the ensurer checks.
+  - Report errors for elements of a collection with Slice, Map, or SliceEach so they are added to the record with
+    paths such as "items[2].price".
+
+CheckInvariants checks an ensurer against these rules and is intended to be run from the tests and fuzz tests of
+packages that provide ensurers.
+*/
+package ensure
@@ -0,0 +1,37 @@
+package ensure
+
+import (
+	"fmt"
+)
+
+// FieldCipher encrypts a single field value. Implementations typically use envelope encryption: a data key from a key
+// management service encrypts plaintext and the wrapped data key is stored alongside the ciphertext.
+type FieldCipher interface {
+	Encrypt(plaintext []byte) (ciphertext []byte, err error)
+}
+
+// Encrypt returns a Ensurer that replaces value with its ciphertext from cipher. It is intended to be last in the chain
+// for fields holding personal data so plaintext never reaches the layers after validation. value must be a string or
+// []byte. The result is a []byte. An error from cipher is returned wrapped. If value is nil then nil is returned.
+func Encrypt(cipher FieldCipher) Ensurer {
+	return describe("encrypt", nil, EnsurerFunc(func(value any) (any, error) {
+		var plaintext []byte
+		switch value := value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			plaintext = []byte(value)
+		case []byte:
+			plaintext = value
+		default:
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		ciphertext, err := cipher.Encrypt(plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt: %w", err)
+		}
+
+		return ciphertext, nil
+	}))
+}
@@ -0,0 +1,51 @@
+package ensure_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reverseCipher struct{}
+
+func (reverseCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, errors.New("empty plaintext")
+	}
+	ciphertext := bytes.Clone(plaintext)
+	for i, j := 0, len(ciphertext)-1; i < j; i, j = i+1, j-1 {
+		ciphertext[i], ciphertext[j] = ciphertext[j], ciphertext[i]
+	}
+	return ciphertext, nil
+}
+
+func TestEncrypt(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"123-45-6789", []byte("9876-54-321"), true},
+		{[]byte("abc"), []byte("cba"), true},
+		{"", nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Encrypt(reverseCipher{}).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	record := ensure.GetterSetterMap{"ssn": " 123-45-6789 "}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("ssn", ensure.SingleLineString(), ensure.MaxLen(11), ensure.Encrypt(reverseCipher{}))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("9876-54-321"), record["ssn"])
+}
@@ -1,14 +1,19 @@
 package ensure
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/errortree"
@@ -30,36 +35,211 @@ func (m GetterSetterMap) Set(key string, value any) {
 	m[key] = value
 }
 
+// Keys returns the keys of m in no particular order.
+func (m GetterSetterMap) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Delete deletes key from m.
+func (m GetterSetterMap) Delete(key string) {
+	delete(m, key)
+}
+
+// KeysGetterSetter is a GetterSetter that can list its attributes. It is required by RecordWithErrors.AllowOnly.
+type KeysGetterSetter interface {
+	GetterSetter
+	Keys() []string
+}
+
+// DeleteGetterSetter is a KeysGetterSetter that can delete attributes. It is required by RecordWithErrors.Permit.
+type DeleteGetterSetter interface {
+	KeysGetterSetter
+	Delete(attribute string)
+}
+
 type RecordWithErrors struct {
-	record GetterSetter
-	errors *errortree.Node
+	ctx         context.Context
+	record      GetterSetter
+	errors      *errortree.Node
+	fieldErrors []*FieldError
+	labels      map[string]string
+
+	// overlay is true while the fields of a RecordEnsurer given to Overlay are ensured.
+	overlay bool
+
+	countCoercions bool
+	coercions      int
+
+	profile map[string]*FieldProfile
+
+	concurrency int
+	sem         chan struct{}
+	wg          sync.WaitGroup
+	pending     []*pendingField
+
+	description *recordDescription
 }
 
-func Record(record GetterSetter, fn EnsureRecordFunc) error {
-	rwe := &RecordWithErrors{
-		record: record,
+// Path is the location of a value within a record. Each segment is either a string field name or an int slice index.
+type Path []any
+
+// String returns p formatted like "items[2].price".
+func (p Path) String() string {
+	sb := &strings.Builder{}
+	for i, segment := range p {
+		if index, ok := segment.(int); ok {
+			fmt.Fprintf(sb, "[%d]", index)
+			continue
+		}
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		fmt.Fprint(sb, segment)
+	}
+	return sb.String()
+}
+
+// append returns a new Path with segments appended to p. p is not modified.
+func (p Path) append(segments ...any) Path {
+	newPath := make(Path, 0, len(p)+len(segments))
+	newPath = append(newPath, p...)
+	return append(newPath, segments...)
+}
+
+// FieldError is an error for a single field of a record. Path is the location of the field. It has more than one
+// segment when the error occurred in a nested record or slice element. It is empty for an error added with
+// RecordWithErrors.AddRecordError. Label is the display name of the field set with RecordWithErrors.Label, if any.
+type FieldError struct {
+	Path  Path
+	Label string
+	Err   error
+}
+
+// Error returns the error prefixed with Label, or Path if there is no Label, such as "Date of birth: required".
+func (e *FieldError) Error() string {
+	if e.Label != "" {
+		return e.Label + ": " + e.Err.Error()
 	}
+	if len(e.Path) == 0 {
+		return e.Err.Error()
+	}
+	return e.Path.String() + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// RecordErrors is the error returned by Record when one or more fields could not be ensured. It unwraps to the
+// *errortree.Node returned by RecordWithErrors.Errors.
+type RecordErrors struct {
+	tree        *errortree.Node
+	fieldErrors []*FieldError
+}
+
+func (e *RecordErrors) Error() string {
+	return e.tree.Error()
+}
+
+func (e *RecordErrors) Unwrap() error {
+	return e.tree
+}
+
+// FieldErrors returns all errors in the order they were added.
+func (e *RecordErrors) FieldErrors() []*FieldError {
+	return e.fieldErrors
+}
+
+// ByField returns the errors grouped by the string form of their path such as "items[2].price". Errors added with
+// RecordWithErrors.AddRecordError are under "". The errors for each field are in the order they were added.
+func (e *RecordErrors) ByField() map[string][]*FieldError {
+	byField := make(map[string][]*FieldError)
+	for _, fe := range e.fieldErrors {
+		field := fe.Path.String()
+		byField[field] = append(byField[field], fe)
+	}
+	return byField
+}
+
+// Fields returns the string form of the path of each field with an error in the order their first error was added.
+// Each field is only returned once.
+func (e *RecordErrors) Fields() []string {
+	var fields []string
+	seen := make(map[string]struct{})
+	for _, fe := range e.fieldErrors {
+		field := fe.Path.String()
+		if _, ok := seen[field]; !ok {
+			seen[field] = struct{}{}
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func Record(record GetterSetter, fn EnsureRecordFunc) error {
+	return recordPhases(context.Background(), record, []EnsureRecordFunc{fn})
+}
 
-	fn(rwe)
+// recordPhases runs each phase in order against record, stopping after the first phase that adds any errors.
+func recordPhases(ctx context.Context, record GetterSetter, phases []EnsureRecordFunc) error {
+	return runPhases(&RecordWithErrors{ctx: ctx, record: record}, phases)
+}
+
+func runPhases(rwe *RecordWithErrors, phases []EnsureRecordFunc) error {
+	for _, fn := range phases {
+		fn(rwe)
+		rwe.wait()
+		if rwe.Errors() != nil {
+			break
+		}
+	}
 
 	if errs := rwe.Errors(); errs != nil {
-		return errs
+		return &RecordErrors{tree: errs, fieldErrors: rwe.fieldErrors}
 	}
 
 	return nil
 }
 
 type RecordEnsurer struct {
-	fn EnsureRecordFunc
+	phases []EnsureRecordFunc
+
+	auditRecordType string
+	auditSink       AuditSink
+	failureCapture  *failureCapture
+	concurrency     int
+	budget          *Budget
 }
 
 func NewRecordEnsurer(fn EnsureRecordFunc) *RecordEnsurer {
 	return &RecordEnsurer{
-		fn: fn,
+		phases: []EnsureRecordFunc{fn},
 	}
 }
 
+// Then returns a copy of re with fn added as a later phase. A phase only runs if all earlier phases added no errors.
+// This allows cheap syntactic checks such as parsing and normalization to run before expensive ones such as database
+// lookups, which are skipped for records that are already invalid. e.g.
+//
+//	ensure.NewRecordEnsurer(parse).Then(validate).Then(checkDatabase)
+func (re *RecordEnsurer) Then(fn EnsureRecordFunc) *RecordEnsurer {
+	newRE := *re
+	newRE.phases = make([]EnsureRecordFunc, 0, len(re.phases)+1)
+	newRE.phases = append(newRE.phases, re.phases...)
+	newRE.phases = append(newRE.phases, fn)
+	return &newRE
+}
+
 func (re *RecordEnsurer) Ensure(value any) (any, error) {
+	return re.EnsureContext(context.Background(), value)
+}
+
+// EnsureContext is like Ensure but makes ctx available to the record's ensurers. See RecordContext.
+func (re *RecordEnsurer) EnsureContext(ctx context.Context, value any) (any, error) {
 	var record GetterSetter
 
 	switch value := value.(type) {
@@ -68,10 +248,10 @@ func (re *RecordEnsurer) Ensure(value any) (any, error) {
 	case map[string]any:
 		record = GetterSetterMap(value)
 	default:
-		return nil, errors.New("not a record")
+		return nil, NewError("not_a_record", "not a record", nil)
 	}
 
-	err := Record(record, re.fn)
+	err := re.ensureRecord(ctx, record)
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +259,126 @@ func (re *RecordEnsurer) Ensure(value any) (any, error) {
 	return value, nil
 }
 
+func (re *RecordEnsurer) ensureRecord(ctx context.Context, record GetterSetter) error {
+	ctx = re.budgetContext(ctx)
+
+	if re.auditSink == nil && re.failureCapture == nil {
+		return runPhases(&RecordWithErrors{ctx: ctx, record: record, concurrency: re.concurrency}, re.phases)
+	}
+
+	var recorder *recordingGetterSetter
+	if re.failureCapture != nil {
+		recorder = &recordingGetterSetter{GetterSetter: record, originals: map[string]any{}}
+		record = recorder
+	}
+
+	rwe := &RecordWithErrors{ctx: ctx, record: record, countCoercions: re.auditSink != nil, concurrency: re.concurrency}
+	start := time.Now()
+	err := runPhases(rwe, re.phases)
+
+	if re.auditSink != nil {
+		re.audit(rwe, err, time.Since(start))
+	}
+	if err != nil && re.failureCapture != nil {
+		re.failureCapture.capture(recorder.originals, rwe.fieldErrors)
+	}
+
+	return err
+}
+
 type EnsureRecordFunc func(*RecordWithErrors)
 
+// Nested returns a Ensurer that ensures a field whose value is itself a record (a map[string]any or GetterSetter) with
+// fn. When used with RecordWithErrors.Ensure, errors in the nested record are added to the parent record with paths
+// such as "address.zip". If value is nil then nil is returned.
+func Nested(fn EnsureRecordFunc) Ensurer {
+	recordEnsurer := NewRecordEnsurer(fn)
+
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		return recordEnsurer.EnsureContext(ctx, value)
+	})
+
+	return describe("nested", map[string]any{"record": recordEnsurer}, ensurer)
+}
+
+// Add adds err to field. If err is a *RecordErrors, as returned by a nested record, or the error returned by Slice or
+// Map, each of its errors is added with a path relative to field instead.
 func (r *RecordWithErrors) Add(field string, err error) {
+	r.addPath(Path{field}, "", err)
+}
+
+// AddRecordError adds err to the record as a whole instead of to a field. It is intended for invariants that involve
+// the whole record such as "at least one contact method must be given". Such checks usually belong in a phase added
+// with RecordEnsurer.Then so they only run once all fields are valid. e.g.
+//
+//	ensure.NewRecordEnsurer(ensureFields).Then(func(r *ensure.RecordWithErrors) {
+//		if r.Get("email") == nil && r.Get("phone") == nil {
+//			r.AddRecordError(ensure.NewError("missing_contact", "email or phone is required", nil))
+//		}
+//	})
+//
+// When the record is nested in another record the error is added to the field that contains it.
+func (r *RecordWithErrors) AddRecordError(err error) {
+	r.addPath(Path{}, "", err)
+}
+
+// addPath adds err at path. label is the label of the field if it was set by a nested record.
+func (r *RecordWithErrors) addPath(path Path, label string, err error) {
+	var nested *RecordErrors
+	if errors.As(err, &nested) {
+		for _, fe := range nested.fieldErrors {
+			r.addPath(path.append(fe.Path...), fe.Label, fe.Err)
+		}
+		return
+	}
+
+	var elErrs sliceElementErrors
+	if errors.As(err, &elErrs) {
+		for _, ee := range elErrs {
+			r.addPath(path.append(ee.Index), "", ee.Err)
+		}
+		return
+	}
+
+	var entryErrs mapEntryErrors
+	if errors.As(err, &entryErrs) {
+		for _, ee := range entryErrs {
+			r.addPath(path.append(ee.Key), "", ee.Err)
+		}
+		return
+	}
+
 	if r.errors == nil {
 		r.errors = &errortree.Node{}
 	}
-	r.errors.Add([]any{field}, err)
+	r.errors.Add(path, err)
+	if l, ok := r.labels[path.String()]; ok {
+		label = l
+	}
+	r.fieldErrors = append(r.fieldErrors, &FieldError{Path: path, Label: label, Err: err})
+}
+
+// Label sets the display name of field to label such as "Date of birth" for "dob". It is used as the Label of the
+// FieldError for errors added to field after Label is called. field may be a path such as "items[0].price" to label an
+// element or a field of a nested record.
+func (r *RecordWithErrors) Label(field, label string) {
+	if r.labels == nil {
+		r.labels = make(map[string]string)
+	}
+	r.labels[field] = label
+}
+
+// Metadata adds metadata to field such as {"group": "shipping", "section": "Address", "order": 3}. Keys already set
+// for field are replaced. It does not affect ensuring a record. It is reported by RecordEnsurer.Rules so generated
+// forms and error summaries can be organized without a parallel data structure.
+func (r *RecordWithErrors) Metadata(field string, metadata map[string]any) {
+	if r.description != nil {
+		r.description.addMetadata(field, metadata)
+	}
 }
 
 func (r *RecordWithErrors) Get(field string) any {
@@ -97,22 +390,155 @@ func (r *RecordWithErrors) Set(field string, value any) {
 }
 
 func (r *RecordWithErrors) Ensure(field string, ensurers ...Ensurer) {
-	value := r.record.Get(field)
+	r.EnsureAs(field, field, ensurers...)
+}
+
+// EnsureAs ensures sourceField like Ensure but sets the result as targetField. This maps the names of an external
+// payload to internal names. e.g. r.EnsureAs("firstName", "first_name", ensure.String()). Errors are added to
+// sourceField since that is the name the input used. sourceField is not modified or removed. Use Permit to remove it.
+func (r *RecordWithErrors) EnsureAs(sourceField, targetField string, ensurers ...Ensurer) {
+	if r.description != nil {
+		r.description.add(sourceField, ensurers)
+		return
+	}
+
+	if r.overlay && r.hasErrors(sourceField) {
+		return
+	}
+
+	if r.concurrency > 1 {
+		r.ensureConcurrently(sourceField, targetField, ensurers)
+		return
+	}
+
+	if r.profile != nil {
+		defer r.profileField(targetField)()
+	}
+
+	original := r.record.Get(sourceField)
+	value, err := r.ensureValue(sourceField, original, ensurers)
+	r.setEnsured(sourceField, targetField, original, value, err)
+}
+
+// ensureValue applies the ensurers of field to value in order and stops at the first error. Checks that were not
+// checked are added as warnings and value is passed unchanged to the next ensurer.
+func (r *RecordWithErrors) ensureValue(field string, value any, ensurers []Ensurer) (any, error) {
+	ctx := warningContext(r.Context(), field)
 	for _, ensurer := range ensurers {
 		var err error
-		value, err = ensurer.Ensure(value)
+		value, err = ensureOrWarn(ctx, ensurer, value)
 		if err != nil {
-			r.Add(field, err)
-			return
+			return nil, err
 		}
 	}
-	r.record.Set(field, value)
+	return value, nil
+}
+
+// setEnsured adds err to sourceField if it is not nil. Otherwise it sets targetField to value.
+func (r *RecordWithErrors) setEnsured(sourceField, targetField string, original, value any, err error) {
+	if err != nil {
+		r.Add(sourceField, err)
+		return
+	}
+	if r.countCoercions && !reflect.DeepEqual(original, value) {
+		r.coercions++
+	}
+	r.record.Set(targetField, value)
+}
+
+// EnsureEach ensures each of fields with the same ensurers. e.g.
+//
+//	r.EnsureEach([]string{"first_name", "last_name", "city"}, ensure.SingleLineString(), ensure.MaxLen(255))
+func (r *RecordWithErrors) EnsureEach(fields []string, ensurers ...Ensurer) {
+	for _, field := range fields {
+		r.Ensure(field, ensurers...)
+	}
+}
+
+// EnsureIf ensures field like Ensure but only if cond returns true. cond is called with r so it can depend on other
+// fields. Fields cond depends on should be ensured first so it sees their converted values. When a RecordEnsurer is
+// described, such as by Rules, cond is not called and the rules of ensurers have the param "if" set to true.
+func (r *RecordWithErrors) EnsureIf(cond func(r *RecordWithErrors) bool, field string, ensurers ...Ensurer) {
+	if r.description != nil {
+		r.Ensure(field, conditional(ensurers)...)
+		return
+	}
+
+	if cond(r) {
+		r.Ensure(field, ensurers...)
+	}
+}
+
+// RequireIf ensures that field is not nil or empty if cond returns true. e.g.
+//
+//	r.RequireIf(ensure.FieldEquals("country", "US"), "state")
+func (r *RecordWithErrors) RequireIf(cond func(r *RecordWithErrors) bool, field string) {
+	r.EnsureIf(cond, field, Require())
+}
+
+// FieldEquals returns a condition for EnsureIf and RequireIf that is true if field is equal to value as determined by
+// reflect.DeepEqual.
+func FieldEquals(field string, value any) func(r *RecordWithErrors) bool {
+	return func(r *RecordWithErrors) bool {
+		return reflect.DeepEqual(r.Get(field), value)
+	}
 }
 
 func (r *RecordWithErrors) Errors() *errortree.Node {
 	return r.errors
 }
 
+// Error is the error type returned by the built-in ensurers. Code is a stable, machine-readable identifier for the kind
+// of failure (e.g. "too_long") and Params holds any values that describe it (e.g. {"max": 10}). The message is for
+// humans and may change; Code and Params are intended to be mapped to client-facing codes and translations.
+type Error struct {
+	Code   string
+	Params map[string]any
+
+	message string
+}
+
+// NewError returns a new *Error. It is intended for use by custom ensurers that want to report errors the same way the
+// built-in ensurers do.
+func NewError(code, message string, params map[string]any) *Error {
+	return &Error{
+		Code:    code,
+		Params:  params,
+		message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Is reports whether target is an *Error with the same Code as e. This allows errors.Is to compare an error returned by
+// an ensurer with the sentinel errors such as ErrRequired regardless of its message and params.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Sentinel errors for common codes. Use with errors.Is. e.g. errors.Is(fieldErr, ensure.ErrRequired). Any *Error with
+// the same Code matches, including those created by custom ensurers with NewError.
+var (
+	ErrRequired     = NewError("required", "cannot be nil or empty", nil)
+	ErrNotAString   = NewError("not_a_string", "not a string", nil)
+	ErrNotANumber   = NewError("not_a_number", "not a valid number", nil)
+	ErrNotABoolean  = NewError("not_a_boolean", "not a valid boolean", nil)
+	ErrNotASlice    = NewError("not_a_slice", "cannot convert to slice", nil)
+	ErrTooShort     = NewError("too_short", "too short", nil)
+	ErrTooLong      = NewError("too_long", "too long", nil)
+	ErrTooSmall     = NewError("too_small", "too small", nil)
+	ErrTooLarge     = NewError("too_large", "too large", nil)
+	ErrNotAllowed   = NewError("not_allowed", "not allowed value", nil)
+	ErrInvalidType  = NewError("invalid_type", "invalid type", nil)
+	ErrNoMatch      = NewError("no_match", "does not match", nil)
+	ErrDuplicate    = NewError("duplicate", "duplicate", nil)
+	ErrTaken        = NewError("taken", "is already taken", nil)
+	ErrUnknownField = NewError("unknown_field", "is not an allowed field", nil)
+)
+
 type Ensurer interface {
 	Ensure(any) (any, error)
 }
@@ -124,6 +550,10 @@ func (fn EnsurerFunc) Ensure(v any) (any, error) {
 }
 
 func convertInt64(value any) (int64, error) {
+	if n, ok := value.(json.Number); ok {
+		value = normalizeJSONNumber(n)
+	}
+
 	switch value := value.(type) {
 	case int8:
 		return int64(value), nil
@@ -141,42 +571,42 @@ func convertInt64(value any) (int64, error) {
 		return int64(value), nil
 	case uint64:
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int64(math.MaxInt64)})
 		}
 		return int64(value), nil
 	case int:
 		if int64(value) < math.MinInt64 {
-			return 0, errors.New("less than minimum allowed number")
+			return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int64(math.MinInt64)})
 		}
 		if int64(value) > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int64(math.MaxInt64)})
 		}
 		return int64(value), nil
 	case uint:
 		if uint64(value) > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int64(math.MaxInt64)})
 		}
 		return int64(value), nil
 	case float32:
 		if value < math.MinInt64 {
-			return 0, errors.New("less than minimum allowed number")
+			return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int64(math.MinInt64)})
 		}
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int64(math.MaxInt64)})
 		}
 		if float32(int64(value)) != value {
-			return 0, errors.New("not a valid number")
+			return 0, NewError("not_a_number", "not a valid number", nil)
 		}
 		return int64(value), nil
 	case float64:
 		if value < math.MinInt64 {
-			return 0, errors.New("less than minimum allowed number")
+			return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int64(math.MinInt64)})
 		}
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int64(math.MaxInt64)})
 		}
 		if float64(int64(value)) != value {
-			return 0, errors.New("not a valid number")
+			return 0, NewError("not_a_number", "not a valid number", nil)
 		}
 		return int64(value), nil
 	}
@@ -186,14 +616,14 @@ func convertInt64(value any) (int64, error) {
 
 	num, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return 0, errors.New("not a valid number")
+		return 0, NewError("not_a_number", "not a valid number", nil)
 	}
 	return num, nil
 }
 
 // Int64 returns a Ensurer that converts value to an int64. If value is nil or a blank string nil is returned.
 func Int64() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("int64", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -206,7 +636,7 @@ func Int64() Ensurer {
 		}
 
 		return n, nil
-	})
+	}))
 }
 
 func convertInt32(value any) (int32, error) {
@@ -216,10 +646,10 @@ func convertInt32(value any) (int32, error) {
 	}
 
 	if n < math.MinInt32 {
-		return 0, errors.New("less than minimum allowed number")
+		return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int32(math.MinInt32)})
 	}
 	if n > math.MaxInt32 {
-		return 0, errors.New("greater than maximum allowed number")
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int32(math.MaxInt32)})
 	}
 
 	return int32(n), nil
@@ -227,7 +657,7 @@ func convertInt32(value any) (int32, error) {
 
 // Int32 returns a Ensurer that converts value to an int32. If value is nil or a blank string nil is returned.
 func Int32() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("int32", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -240,7 +670,287 @@ func Int32() Ensurer {
 		}
 
 		return n, nil
-	})
+	}))
+}
+
+func convertInt16(value any) (int16, error) {
+	n, err := convertInt64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < math.MinInt16 {
+		return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int16(math.MinInt16)})
+	}
+	if n > math.MaxInt16 {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int16(math.MaxInt16)})
+	}
+
+	return int16(n), nil
+}
+
+// Int16 returns a Ensurer that converts value to an int16. If value is nil or a blank string nil is returned.
+func Int16() Ensurer {
+	return describe("int16", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertInt16(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertInt8(value any) (int8, error) {
+	n, err := convertInt64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < math.MinInt8 {
+		return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int8(math.MinInt8)})
+	}
+	if n > math.MaxInt8 {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int8(math.MaxInt8)})
+	}
+
+	return int8(n), nil
+}
+
+// Int8 returns a Ensurer that converts value to an int8. If value is nil or a blank string nil is returned.
+func Int8() Ensurer {
+	return describe("int8", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertInt8(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertInt(value any) (int, error) {
+	n, err := convertInt64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < math.MinInt {
+		return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": int(math.MinInt)})
+	}
+	if n > math.MaxInt {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": int(math.MaxInt)})
+	}
+
+	return int(n), nil
+}
+
+// Int returns a Ensurer that converts value to an int. If value is nil or a blank string nil is returned.
+func Int() Ensurer {
+	return describe("int", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertInt(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertUint64(value any) (uint64, error) {
+	tooSmall := NewError("too_small", "less than minimum allowed number", map[string]any{"min": uint64(0)})
+
+	if n, ok := value.(json.Number); ok {
+		value = normalizeJSONNumber(n)
+	}
+
+	switch value := value.(type) {
+	case uint8:
+		return uint64(value), nil
+	case uint16:
+		return uint64(value), nil
+	case uint32:
+		return uint64(value), nil
+	case uint64:
+		return value, nil
+	case uint:
+		return uint64(value), nil
+	case float32:
+		if value < 0 {
+			return 0, tooSmall
+		}
+		if value >= math.MaxUint64 {
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint64(math.MaxUint64)})
+		}
+		if float32(uint64(value)) != value {
+			return 0, NewError("not_a_number", "not a valid number", nil)
+		}
+		return uint64(value), nil
+	case float64:
+		if value < 0 {
+			return 0, tooSmall
+		}
+		if value >= math.MaxUint64 {
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint64(math.MaxUint64)})
+		}
+		if float64(uint64(value)) != value {
+			return 0, NewError("not_a_number", "not a valid number", nil)
+		}
+		return uint64(value), nil
+	case int8, int16, int32, int64, int:
+		n, err := convertInt64(value)
+		if err != nil {
+			return 0, err
+		}
+		if n < 0 {
+			return 0, tooSmall
+		}
+		return uint64(n), nil
+	}
+
+	s := fmt.Sprintf("%v", value)
+	s = strings.TrimSpace(s)
+
+	num, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint64(math.MaxUint64)})
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n < 0 {
+			return 0, tooSmall
+		}
+		return 0, NewError("not_a_number", "not a valid number", nil)
+	}
+	return num, nil
+}
+
+// Uint64 returns a Ensurer that converts value to a uint64. If value is nil or a blank string nil is returned.
+func Uint64() Ensurer {
+	return describe("uint64", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertUint64(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertUint32(value any) (uint32, error) {
+	n, err := convertUint64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > math.MaxUint32 {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint32(math.MaxUint32)})
+	}
+
+	return uint32(n), nil
+}
+
+// Uint32 returns a Ensurer that converts value to a uint32. If value is nil or a blank string nil is returned.
+func Uint32() Ensurer {
+	return describe("uint32", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertUint32(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertUint16(value any) (uint16, error) {
+	n, err := convertUint64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > math.MaxUint16 {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint16(math.MaxUint16)})
+	}
+
+	return uint16(n), nil
+}
+
+// Uint16 returns a Ensurer that converts value to a uint16. If value is nil or a blank string nil is returned.
+func Uint16() Ensurer {
+	return describe("uint16", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertUint16(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
+}
+
+func convertUint8(value any) (uint8, error) {
+	n, err := convertUint64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > math.MaxUint8 {
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": uint8(math.MaxUint8)})
+	}
+
+	return uint8(n), nil
+}
+
+// Uint8 returns a Ensurer that converts value to a uint8. If value is nil or a blank string nil is returned.
+func Uint8() Ensurer {
+	return describe("uint8", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertUint8(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}))
 }
 
 func convertFloat64(value any) (float64, error) {
@@ -276,14 +986,14 @@ func convertFloat64(value any) (float64, error) {
 
 	num, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		return 0, errors.New("not a valid number")
+		return 0, NewError("not_a_number", "not a valid number", nil)
 	}
 	return num, nil
 }
 
 // Float64 returns a Ensurer that converts value to an float64. If value is nil or a blank string nil is returned.
 func Float64() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("float64", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -296,7 +1006,7 @@ func Float64() Ensurer {
 		}
 
 		return n, nil
-	})
+	}))
 }
 
 func convertFloat32(value any) (float32, error) {
@@ -306,10 +1016,10 @@ func convertFloat32(value any) (float32, error) {
 	}
 
 	if n < -math.MaxFloat32 {
-		return 0, errors.New("less than minimum allowed number")
+		return 0, NewError("too_small", "less than minimum allowed number", map[string]any{"min": float32(-math.MaxFloat32)})
 	}
 	if n > math.MaxFloat32 {
-		return 0, errors.New("greater than maximum allowed number")
+		return 0, NewError("too_large", "greater than maximum allowed number", map[string]any{"max": float32(math.MaxFloat32)})
 	}
 
 	return float32(n), nil
@@ -318,7 +1028,7 @@ func convertFloat32(value any) (float32, error) {
 // Float32 returns a Ensurer that converts value to an float32. If value is nil or a blank string nil is
 // returned.
 func Float32() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("float32", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -331,12 +1041,12 @@ func Float32() Ensurer {
 		}
 
 		return n, nil
-	})
+	}))
 }
 
 // Bool returns a Ensurer that converts value to a bool. If value is nil or a blank string nil is returned.
 func Bool() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("bool", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -350,43 +1060,166 @@ func Bool() Ensurer {
 			value = strings.TrimSpace(value)
 			b, err := strconv.ParseBool(value)
 			if err != nil {
-				return nil, err
+				return nil, NewError("not_a_boolean", "not a valid boolean", nil)
 			}
 			return b, nil
 		default:
-			return nil, errors.New("not a valid boolean")
+			return nil, NewError("not_a_boolean", "not a valid boolean", nil)
 		}
-	})
+	}))
 }
 
-// Time returns a Ensurer that converts value to a time.Time using formats. If value is nil or a blank string nil is returned.
-func Time(formats ...string) Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+// BoolNullable returns a Ensurer that converts value to a bool like Bool, except that the strings "null" and "unknown"
+// (case insensitive) are also converted to nil. It is intended for three-valued fields where an explicit false must be
+// distinguished from an absent value.
+func BoolNullable() Ensurer {
+	boolEnsurer := Bool()
+
+	return describe("boolnullable", nil, EnsurerFunc(func(value any) (any, error) {
+		if s, ok := value.(string); ok {
+			switch strings.ToLower(strings.TrimSpace(s)) {
+			case "null", "unknown":
+				return nil, nil
+			}
+		}
+
+		return boolEnsurer.Ensure(value)
+	}))
+}
+
+// TimeEnsurer is a Ensurer that converts value to a time.Time. It is returned by Time.
+type TimeEnsurer struct {
+	formats     []string
+	location    *time.Location
+	convertTo   *time.Location
+	unixSeconds bool
+}
+
+// Time returns a *TimeEnsurer that converts value to a time.Time using formats. Times without a time zone are parsed
+// as UTC unless InLocation is used. If value is nil or a blank string nil is returned.
+func Time(formats ...string) *TimeEnsurer {
+	return &TimeEnsurer{formats: formats, location: time.UTC}
+}
+
+// InLocation returns a copy of te that parses times without a time zone in loc instead of UTC. Unix epoch times are also
+// returned in loc.
+func (te *TimeEnsurer) InLocation(loc *time.Location) *TimeEnsurer {
+	newTE := *te
+	newTE.location = loc
+	return &newTE
+}
+
+// ConvertTo returns a copy of te that converts the resulting time to loc.
+func (te *TimeEnsurer) ConvertTo(loc *time.Location) *TimeEnsurer {
+	newTE := *te
+	newTE.convertTo = loc
+	return &newTE
+}
+
+// AcceptUnixSeconds returns a copy of te that also accepts numbers, or strings that do not match any format but are
+// numbers, as seconds since the Unix epoch. Fractional seconds are allowed.
+func (te *TimeEnsurer) AcceptUnixSeconds() *TimeEnsurer {
+	newTE := *te
+	newTE.unixSeconds = true
+	return &newTE
+}
+
+func (te *TimeEnsurer) Describe() Rule {
+	return Rule{Kind: "time", Params: map[string]any{"formats": te.formats, "unix_seconds": te.unixSeconds}}
+}
+
+func (te *TimeEnsurer) Ensure(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	t, ok := te.parse(value)
+	if !ok {
+		return nil, NewError("not_a_time", "not a valid time", nil)
+	}
+
+	if te.convertTo != nil {
+		t = t.In(te.convertTo)
+	}
+
+	return t, nil
+}
+
+func (te *TimeEnsurer) parse(value any) (time.Time, bool) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, true
+	case string:
+		for _, format := range te.formats {
+			t, err := time.ParseInLocation(format, value, te.location)
+			if err == nil {
+				return t, true
+			}
+		}
+	}
+
+	if te.unixSeconds {
+		if n, err := convertInt64(value); err == nil {
+			return time.Unix(n, 0).In(te.location), true
+		}
+		// -2^63 and 2^63 are exact as float64. The range check is false for NaN.
+		if n, err := convertFloat64(value); err == nil && n >= math.MinInt64 && n < -math.MinInt64 {
+			sec, frac := math.Modf(n)
+			return time.Unix(int64(sec), int64(math.Round(frac*1e9))).In(te.location), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Date returns a Ensurer that converts value to a date-only time.Time at midnight UTC using formats. If no formats are
+// given then "2006-01-02" is used. Values that have a time of day are rejected. If value is nil or a blank string nil
+// is returned.
+func Date(formats ...string) Ensurer {
+	if len(formats) == 0 {
+		formats = []string{"2006-01-02"}
+	}
+
+	return describe("date", map[string]any{"formats": formats}, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
 			return nil, nil
 		}
 
+		var t time.Time
+		var ok bool
 		switch value := value.(type) {
 		case time.Time:
-			return value, nil
+			t, ok = value, true
 		case string:
 			for _, format := range formats {
-				t, err := time.Parse(format, value)
+				var err error
+				t, err = time.Parse(format, value)
 				if err == nil {
-					return t, nil
+					ok = true
+					break
 				}
 			}
 		}
 
-		return nil, errors.New("not a valid time")
-	})
+		if !ok {
+			return nil, NewError("not_a_date", "not a valid date", nil)
+		}
+
+		if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+			return nil, NewError("not_a_date", "must not have a time of day", nil)
+		}
+
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}))
 }
 
 // UUID returns a Ensurer that converts value to a uuid.UUID. If value is nil or a blank string nil is returned.
 func UUID() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("uuid", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -396,15 +1229,18 @@ func UUID() Ensurer {
 		var uuidValue uuid.UUID
 		var err error
 
-		if value, ok := value.([]byte); ok {
-			uuidValue, err = uuid.FromBytes(value)
-			return uuidValue, err
+		if b, ok := value.([]byte); ok {
+			uuidValue, err = uuid.FromBytes(b)
+		} else {
+			s := fmt.Sprintf("%v", value)
+			uuidValue, err = uuid.FromString(s)
+		}
+		if err != nil {
+			return nil, NewError("not_a_uuid", "not a valid UUID", nil)
 		}
 
-		s := fmt.Sprintf("%v", value)
-		uuidValue, err = uuid.FromString(s)
-		return uuidValue, err
-	})
+		return uuidValue, nil
+	}))
 }
 
 func convertDecimal(value any) (decimal.Decimal, error) {
@@ -434,7 +1270,7 @@ func convertDecimal(value any) (decimal.Decimal, error) {
 // Decimal returns a Ensurer that converts value to a decimal.Decimal. If value is nil or a blank string nil is
 // returned.
 func Decimal() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("decimal", nil, EnsurerFunc(func(value any) (any, error) {
 		value = normalizeForParsing(value)
 
 		if value == nil {
@@ -442,12 +1278,91 @@ func Decimal() Ensurer {
 		}
 
 		n, err := convertDecimal(value)
+		if err != nil {
+			return nil, NewError("not_a_number", "not a valid number", nil)
+		}
+
+		return n, nil
+	}))
+}
+
+func convertBigInt(value any) (*big.Int, error) {
+	notANumber := NewError("not_a_number", "not a valid number", nil)
+
+	if n, ok := value.(json.Number); ok {
+		value = normalizeJSONNumber(n)
+	}
+
+	switch value := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(value), nil
+	case big.Int:
+		return new(big.Int).Set(&value), nil
+	case int8, int16, int32, int64, int:
+		n, err := convertInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(n), nil
+	case uint8, uint16, uint32, uint64, uint:
+		n, err := convertUint64(value)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(n), nil
+	case float32:
+		return convertBigInt(float64(value))
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) || value != math.Trunc(value) {
+			return nil, notANumber
+		}
+		n, _ := big.NewFloat(value).Int(nil)
+		return n, nil
+	case decimal.Decimal:
+		if !value.Equal(value.Truncate(0)) {
+			return nil, notANumber
+		}
+		return value.BigInt(), nil
+	}
+
+	s := fmt.Sprintf("%v", value)
+	s = strings.TrimSpace(s)
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, notANumber
+	}
+	return n, nil
+}
+
+// BigInt returns a Ensurer that converts value to a *big.Int. Unlike Int64 there is no range limit. If value is nil or
+// a blank string nil is returned.
+func BigInt() Ensurer {
+	return describe("bigint", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		n, err := convertBigInt(value)
 		if err != nil {
 			return nil, err
 		}
 
 		return n, nil
-	})
+	}))
+}
+
+// normalizeJSONNumber returns n without a fractional part of zeros or an exponent so JSON numbers such as 1.0 and 1e3
+// can be parsed as integers the same as the float64 values encoding/json would otherwise decode them to. n is returned
+// unmodified if it is not a valid number or its exponent is too large to expand.
+func normalizeJSONNumber(n json.Number) string {
+	d, err := decimal.NewFromString(string(n))
+	if err != nil || d.Exponent() > 64 {
+		return string(n)
+	}
+	return d.String()
 }
 
 func convertString(value any) string {
@@ -464,13 +1379,13 @@ func convertString(value any) string {
 // String returns a Ensurer that converts value to a string. If value is nil then nil is returned. It does not
 // perform any normalization. In almost all cases, SingleLineString or MultiLineString should be used instead.
 func String() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("string", nil, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return value, nil
 		}
 
 		return convertString(value), nil
-	})
+	}))
 }
 
 type sliceElementError struct {
@@ -491,10 +1406,39 @@ func (e sliceElementErrors) Error() string {
 	return sb.String()
 }
 
+// SliceOption configures Slice.
+type SliceOption func(*sliceOptions)
+
+type sliceOptions struct {
+	maxErrors int
+}
+
+// FailFast returns a SliceOption that makes Slice stop at the first element that fails.
+func FailFast() SliceOption {
+	return MaxErrors(1)
+}
+
+// MaxErrors returns a SliceOption that makes Slice stop after n elements have failed. This bounds the work and the
+// size of the error for large payloads such as bulk imports. n must be positive or MaxErrors panics.
+func MaxErrors(n int) SliceOption {
+	if n <= 0 {
+		panic(fmt.Errorf("n must be positive: %d", n))
+	}
+
+	return func(so *sliceOptions) {
+		so.maxErrors = n
+	}
+}
+
 // Slice returns a Ensurer that converts value to a []T. value must be a []T or []any. If value is nil then nil
-// is returned.
-func Slice[T any](elementEnsurer Ensurer) Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+// is returned. By default every element is ensured and all element errors are returned; see FailFast and MaxErrors.
+func Slice[T any](elementEnsurer Ensurer, options ...SliceOption) Ensurer {
+	var so sliceOptions
+	for _, o := range options {
+		o(&so)
+	}
+
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
@@ -506,15 +1450,20 @@ func Slice[T any](elementEnsurer Ensurer) Ensurer {
 			ts := make([]T, len(value))
 			var elErrs sliceElementErrors
 			for i := range value {
-				element, err := elementEnsurer.Ensure(value[i])
+				if so.maxErrors > 0 && len(elErrs) >= so.maxErrors {
+					break
+				}
+
+				element, err := ensureOrWarn(warningContext(ctx, i), elementEnsurer, value[i])
 				if err != nil {
 					elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
+					continue
 				}
 				if element, ok := element.(T); ok {
 					ts[i] = element
 				} else {
 					var zero T
-					elErrs = append(elErrs, sliceElementError{Index: i, Err: fmt.Errorf("not a %T", zero)})
+					elErrs = append(elErrs, sliceElementError{Index: i, Err: NewError("invalid_type", fmt.Sprintf("not a %T", zero), map[string]any{"type": fmt.Sprintf("%T", zero)})})
 				}
 			}
 
@@ -525,37 +1474,39 @@ func Slice[T any](elementEnsurer Ensurer) Ensurer {
 			return ts, nil
 		}
 
-		return nil, fmt.Errorf("cannot convert to slice")
+		return nil, NewError("not_a_slice", "cannot convert to slice", nil)
 	})
+
+	return describe("slice", map[string]any{"element": elementEnsurer}, ensurer)
 }
 
 // NotNil returns a Ensurer that fails if value is nil.
 func NotNil() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("notnil", nil, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
-			return nil, errors.New("cannot be nil")
+			return nil, NewError("required", "cannot be nil", nil)
 		}
 		return value, nil
-	})
+	}))
 }
 
 // Require returns a Ensurer that returns an error if value is nil or "".
 func Require() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("require", nil, EnsurerFunc(func(value any) (any, error) {
 		if value == nil || value == "" {
-			return nil, fmt.Errorf("cannot be nil or empty")
+			return nil, NewError("required", "cannot be nil or empty", nil)
 		}
 
 		return value, nil
-	})
+	}))
 }
 
-func convertSlice(value any, converters []Ensurer) (any, error) {
+func convertSlice(ctx context.Context, value any, converters []Ensurer) (any, error) {
 	v := value
 	var err error
 
 	for _, vc := range converters {
-		v, err = vc.Ensure(v)
+		v, err = ensureOrWarn(ctx, vc, v)
 		if err != nil {
 			break
 		}
@@ -565,13 +1516,71 @@ func convertSlice(value any, converters []Ensurer) (any, error) {
 }
 
 func IfNotNil(converters ...Ensurer) Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
 		if value == nil {
 			return value, nil
 		}
 
-		return convertSlice(value, converters)
+		return convertSlice(ctx, value, converters)
+	})
+
+	return describe("ifnotnil", map[string]any{"ensurers": converters}, ensurer)
+}
+
+// All returns a Ensurer that applies ensurers in order, passing the result of each to the next, and stops at the first
+// error. It allows a common chain to be defined once and reused. e.g.
+//
+//	var Title = ensure.All(ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(100))
+func All(ensurers ...Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		return convertSlice(ctx, value, ensurers)
+	})
+
+	return describe("all", map[string]any{"ensurers": ensurers}, ensurer)
+}
+
+// AnyOf returns a Ensurer that tries each of ensurers in order on value and returns the result of the first that
+// succeeds. e.g. AnyOf(UUID(), Int64()) accepts either a UUID or an integer ID. If all of them fail then the error of
+// the last is returned.
+func AnyOf(ensurers ...Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		err := error(NewError("no_match", "does not match any allowed format", nil))
+		for _, e := range ensurers {
+			var result any
+			result, err = ensureContext(ctx, e, value)
+			if err == nil {
+				return result, nil
+			}
+		}
+
+		return nil, err
+	})
+
+	return describe("anyof", map[string]any{"ensurers": ensurers}, ensurer)
+}
+
+// When returns a Ensurer that applies ensurers in order if pred returns true for value. Otherwise value is returned
+// unmodified. pred is called with every value including nil. e.g.
+//
+//	isHTTP := func(v any) bool { s, _ := v.(string); return strings.HasPrefix(s, "http") }
+//	r.Ensure("website", ensure.When(isHTTP, ensure.URL("http", "https")))
+func When(pred func(value any) bool, ensurers ...Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if !pred(value) {
+			return value, nil
+		}
+
+		return convertSlice(ctx, value, ensurers)
 	})
+
+	return describe("when", map[string]any{"ensurers": ensurers}, ensurer)
+}
+
+// Unless returns a Ensurer that applies ensurers in order unless pred returns true for value. It is the inverse of
+// When.
+func Unless(pred func(value any) bool, ensurers ...Ensurer) Ensurer {
+	return describe("unless", map[string]any{"ensurers": ensurers},
+		When(func(value any) bool { return !pred(value) }, ensurers...))
 }
 
 // SingleLineString returns a Ensurer that converts a string value to a normalized string. If value is nil then nil is
@@ -582,27 +1591,70 @@ func IfNotNil(converters ...Ensurer) Ensurer {
 //   - Replace non-printable characters with standard space
 //   - Remove spaces from left and right
 func SingleLineString() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("singleline", nil, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		if s, ok := value.(string); ok {
-			s = strings.ToValidUTF8(s, "")
-			s = strings.Map(func(r rune) rune {
-				if unicode.IsPrint(r) {
-					return r
-				} else {
-					return ' '
-				}
-			}, s)
-			s = strings.TrimSpace(s)
+			return normalizeSingleLineString(s), nil
+		}
 
-			return s, nil
+		return nil, NewError("not_a_string", "not a string", nil)
+	}))
+}
+
+// normalizeSingleLineString performs the normalization for SingleLineString.
+func normalizeSingleLineString(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPrint(r) {
+			return r
+		} else {
+			return ' '
+		}
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// CollapseSpaces returns a Ensurer that replaces each run of whitespace in value with a single space. It is intended to
+// follow SingleLineString so text pasted with internal runs of spaces or tabs is normalized. e.g. "a    b" becomes
+// "a b". If value is nil then nil is returned. If value is not a string then an error is returned.
+func CollapseSpaces() Ensurer {
+	return describe("collapsespaces", nil, transformString(func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}))
+}
+
+// UserAgent returns a Ensurer that normalizes a User-Agent string the same way as SingleLineString and then truncates
+// it to at most maxLen bytes without splitting a UTF-8 character. If value is nil then nil is returned. If value is not
+// a string then an error is returned. maxLen must not be negative or UserAgent panics.
+func UserAgent(maxLen int) Ensurer {
+	if maxLen < 0 {
+		panic(fmt.Errorf("maxLen must not be negative: %d", maxLen))
+	}
+
+	return describe("useragent", map[string]any{"max": maxLen}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
 		}
 
-		return nil, errors.New("not a string")
-	})
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		s = normalizeSingleLineString(s)
+		if len(s) > maxLen {
+			n := maxLen
+			for n > 0 && !utf8.RuneStart(s[n]) {
+				n--
+			}
+			s = strings.TrimSpace(s[:n])
+		}
+
+		return s, nil
+	}))
 }
 
 // MultiLineString returns a Ensurer that converts a string value to a normalized string. If value is nil then nil is
@@ -612,7 +1664,7 @@ func SingleLineString() Ensurer {
 //   - Remove any invalid UTF-8
 //   - Replace characters that are not graphic or space with standard space
 func MultiLineString() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("multiline", nil, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
@@ -630,8 +1682,8 @@ func MultiLineString() Ensurer {
 			return s, nil
 		}
 
-		return nil, errors.New("not a string")
-	})
+		return nil, NewError("not_a_string", "not a string", nil)
+	}))
 }
 
 // normalizeForParsing prepares value for parsing. If the value is not a string it is returned. Otherwise, space is
@@ -650,20 +1702,20 @@ func normalizeForParsing(value any) any {
 
 // NilifyEmpty converts strings, slices, and maps where len(value) == 0 to nil. Any other value not modified.
 func NilifyEmpty() Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("nilifyempty", nil, EnsurerFunc(func(value any) (any, error) {
 		n, ok := tryLen(value)
 		if ok && n == 0 {
 			return nil, nil
 		}
 		return value, nil
-	})
+	}))
 }
 
 func requireStringTest(test func(string) bool, failErr error) Ensurer {
 	return EnsurerFunc(func(value any) (any, error) {
 		s, ok := value.(string)
 		if !ok {
-			return nil, errors.New("not a string")
+			return nil, NewError("not_a_string", "not a string", nil)
 		}
 
 		if test(s) {
@@ -674,6 +1726,23 @@ func requireStringTest(test func(string) bool, failErr error) Ensurer {
 	})
 }
 
+// transformString returns a Ensurer that applies fn to value. If value is nil then nil is returned. If value is not a
+// string then an error is returned.
+func transformString(fn func(string) string) Ensurer {
+	return EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		return fn(s), nil
+	})
+}
+
 func tryLen(value any) (n int, ok bool) {
 	s, ok := value.(string)
 	if ok {
@@ -692,43 +1761,68 @@ func tryLen(value any) (n int, ok bool) {
 // MinLen returns a Ensurer that fails if len(value) < min. value must be a string, slice, or map. nil is
 // returned unmodified.
 func MinLen(min int) Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("minlen", map[string]any{"min": min}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryLen(value)
 		if !ok {
-			return nil, errors.New("not a string, slice or map")
+			return nil, NewError("invalid_type", "not a string, slice or map", nil)
 		}
 
 		if n < min {
-			return nil, fmt.Errorf("too short")
+			return nil, NewError("too_short", "too short", map[string]any{"min": min})
 		}
 
 		return value, nil
-	})
+	}))
 }
 
 // MaxLen returns a Ensurer that fails if len(value) > max. value must be a string, slice, or map. nil is
 // returned unmodified.
 func MaxLen(max int) Ensurer {
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("maxlen", map[string]any{"max": max}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryLen(value)
 		if !ok {
-			return nil, errors.New("not a string, slice or map")
+			return nil, NewError("invalid_type", "not a string, slice or map", nil)
 		}
 
 		if n > max {
-			return nil, fmt.Errorf("too long")
+			return nil, NewError("too_long", "too long", map[string]any{"max": max})
 		}
 
 		return value, nil
-	})
+	}))
+}
+
+// MaxInputBytes returns a Ensurer that fails if value is a string or []byte longer than n bytes. Any other value is
+// returned unmodified. It is intended to be first in a chain so absurdly long hostile input is rejected before any
+// regular expression, number parsing, or Unicode normalization runs on it. e.g.
+//
+//	r.Ensure("amount", ensure.MaxInputBytes(64), ensure.Decimal())
+func MaxInputBytes(n int) Ensurer {
+	return describe("maxinputbytes", map[string]any{"max_bytes": n}, EnsurerFunc(func(value any) (any, error) {
+		var size int
+		switch value := value.(type) {
+		case string:
+			size = len(value)
+		case []byte:
+			size = len(value)
+		default:
+			return value, nil
+		}
+
+		if size > n {
+			return nil, NewError("too_long", "too long", map[string]any{"max_bytes": n})
+		}
+
+		return value, nil
+	}))
 }
 
 // AllowStrings returns a Ensurer that returns an error unless value is one of the allowedItems. If value is nil
@@ -739,22 +1833,22 @@ func AllowStrings(allowedItems ...string) Ensurer {
 		set[item] = struct{}{}
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("allowstrings", map[string]any{"allowed": allowedItems}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return value, nil
 		}
 
 		s, ok := value.(string)
 		if !ok {
-			return nil, fmt.Errorf("not allowed value")
+			return nil, NewError("not_allowed", "not allowed value", nil)
 		}
 
 		if _, ok := set[s]; !ok {
-			return nil, fmt.Errorf("not allowed value")
+			return nil, NewError("not_allowed", "not allowed value", nil)
 		}
 
 		return value, nil
-	})
+	}))
 }
 
 // ExcludeStrings returns a Ensurer that returns an error if value is one of the excludedItems. If value is nil
@@ -765,24 +1859,148 @@ func ExcludeStrings(excludedItems ...string) Ensurer {
 		set[item] = struct{}{}
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	ensurer := EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return value, nil
 		}
 
 		s, ok := value.(string)
 		if !ok {
-			return nil, fmt.Errorf("not allowed value")
+			return nil, NewError("not_allowed", "not allowed value", nil)
 		}
 
 		if _, ok := set[s]; ok {
-			return nil, fmt.Errorf("not allowed value")
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		return value, nil
+	})
+
+	return describe("excludestrings", map[string]any{"excluded": excludedItems}, ensurer)
+}
+
+// HasPrefix returns a Ensurer that returns an error unless value starts with prefix. If value is nil then nil is
+// returned. If value is not a string then an error is returned.
+func HasPrefix(prefix string) Ensurer {
+	return describe("prefix", map[string]any{"prefix": prefix},
+		stringConstraint(func(s string) bool { return strings.HasPrefix(s, prefix) },
+			"missing_prefix", fmt.Sprintf("must start with %q", prefix), map[string]any{"prefix": prefix}))
+}
+
+// HasSuffix returns a Ensurer that returns an error unless value ends with suffix. If value is nil then nil is returned.
+// If value is not a string then an error is returned.
+func HasSuffix(suffix string) Ensurer {
+	return describe("suffix", map[string]any{"suffix": suffix},
+		stringConstraint(func(s string) bool { return strings.HasSuffix(s, suffix) },
+			"missing_suffix", fmt.Sprintf("must end with %q", suffix), map[string]any{"suffix": suffix}))
+}
+
+// Contains returns a Ensurer that returns an error unless value contains substr. If value is nil then nil is returned.
+// If value is not a string then an error is returned.
+func Contains(substr string) Ensurer {
+	return describe("contains", map[string]any{"substring": substr},
+		stringConstraint(func(s string) bool { return strings.Contains(s, substr) },
+			"missing_substring", fmt.Sprintf("must contain %q", substr), map[string]any{"substring": substr}))
+}
+
+// NotContains returns a Ensurer that returns an error if value contains substr. If value is nil then nil is returned.
+// If value is not a string then an error is returned.
+func NotContains(substr string) Ensurer {
+	return describe("notcontains", map[string]any{"substring": substr},
+		stringConstraint(func(s string) bool { return !strings.Contains(s, substr) },
+			"contains_substring", fmt.Sprintf("must not contain %q", substr), map[string]any{"substring": substr}))
+}
+
+// stringConstraint returns a Ensurer that returns an error with code, message, and params unless test returns true for
+// value. If value is nil then nil is returned. If value is not a string then an error is returned.
+func stringConstraint(test func(string) bool, code, message string, params map[string]any) Ensurer {
+	return EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		if !test(s) {
+			return nil, NewError(code, message, params)
 		}
 
 		return value, nil
 	})
 }
 
+// Equal returns a Ensurer that returns an error unless value is equal to x as determined by reflect.DeepEqual. No
+// conversion is done so value must already be the same type as x. If value is nil then nil is returned.
+func Equal(x any) Ensurer {
+	return describe("equal", map[string]any{"expected": x}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return value, nil
+		}
+
+		if !reflect.DeepEqual(value, x) {
+			return nil, NewError("not_equal", fmt.Sprintf("must be %v", x), map[string]any{"expected": x})
+		}
+
+		return value, nil
+	}))
+}
+
+// OneOf returns a Ensurer that returns an error unless value is one of the allowedItems. Unlike AllowStrings it works
+// with any comparable type such as int status codes or custom string types. No conversion is done so value must already
+// be a T. If value is nil then nil is returned.
+func OneOf[T comparable](allowedItems ...T) Ensurer {
+	set := make(map[T]struct{}, len(allowedItems))
+	for _, item := range allowedItems {
+		set[item] = struct{}{}
+	}
+
+	return describe("oneof", map[string]any{"allowed": allowedItems}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return value, nil
+		}
+
+		v, ok := value.(T)
+		if !ok {
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		if _, ok := set[v]; !ok {
+			return nil, NewError("not_allowed", "not allowed value", map[string]any{"allowed": allowedItems})
+		}
+
+		return value, nil
+	}))
+}
+
+// NotOneOf returns a Ensurer that returns an error if value is one of the excludedItems. Unlike ExcludeStrings it works
+// with any comparable type. No conversion is done so value must already be a T. If value is nil then nil is returned.
+func NotOneOf[T comparable](excludedItems ...T) Ensurer {
+	set := make(map[T]struct{}, len(excludedItems))
+	for _, item := range excludedItems {
+		set[item] = struct{}{}
+	}
+
+	return describe("notoneof", map[string]any{"excluded": excludedItems}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return value, nil
+		}
+
+		v, ok := value.(T)
+		if !ok {
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		if _, ok := set[v]; ok {
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		return value, nil
+	}))
+}
+
 func tryDecimal(value any) (n decimal.Decimal, ok bool) {
 	var strValue string
 	switch value := value.(type) {
@@ -820,22 +2038,22 @@ func LessThan(x any) Ensurer {
 		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("lt", map[string]any{"less_than": x}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryDecimal(value)
 		if !ok {
-			return nil, fmt.Errorf("not a number")
+			return nil, NewError("not_a_number", "not a number", nil)
 		}
 
 		if !n.LessThan(dx) {
-			return nil, fmt.Errorf("too large")
+			return nil, NewError("too_large", "too large", map[string]any{"less_than": x})
 		}
 
 		return value, nil
-	})
+	}))
 }
 
 // LessThanOrEqual returns a Ensurer that fails unless value <= x. x must be convertable to a decimal number or
@@ -846,22 +2064,22 @@ func LessThanOrEqual(x any) Ensurer {
 		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("max", map[string]any{"max": x}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryDecimal(value)
 		if !ok {
-			return nil, fmt.Errorf("not a number")
+			return nil, NewError("not_a_number", "not a number", nil)
 		}
 
 		if !n.LessThanOrEqual(dx) {
-			return nil, fmt.Errorf("too large")
+			return nil, NewError("too_large", "too large", map[string]any{"max": x})
 		}
 
 		return value, nil
-	})
+	}))
 }
 
 // GreaterThan returns a Ensurer that fails unless value > x. x must be convertable to a decimal number or
@@ -872,22 +2090,22 @@ func GreaterThan(x any) Ensurer {
 		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("gt", map[string]any{"greater_than": x}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryDecimal(value)
 		if !ok {
-			return nil, fmt.Errorf("not a number")
+			return nil, NewError("not_a_number", "not a number", nil)
 		}
 
 		if !n.GreaterThan(dx) {
-			return nil, fmt.Errorf("too small")
+			return nil, NewError("too_small", "too small", map[string]any{"greater_than": x})
 		}
 
 		return value, nil
-	})
+	}))
 }
 
 // GreaterThanOrEqual returns a Ensurer that fails unless value >= x. x must be convertable to a decimal number
@@ -898,18 +2116,74 @@ func GreaterThanOrEqual(x any) Ensurer {
 		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
 	}
 
-	return EnsurerFunc(func(value any) (any, error) {
+	return describe("min", map[string]any{"min": x}, EnsurerFunc(func(value any) (any, error) {
 		if value == nil {
 			return nil, nil
 		}
 
 		n, ok := tryDecimal(value)
 		if !ok {
-			return nil, fmt.Errorf("not a number")
+			return nil, NewError("not_a_number", "not a number", nil)
 		}
 
 		if !n.GreaterThanOrEqual(dx) {
-			return nil, fmt.Errorf("too small")
+			return nil, NewError("too_small", "too small", map[string]any{"min": x})
+		}
+
+		return value, nil
+	}))
+}
+
+// Between returns a Ensurer that fails unless min <= value <= max. It is like GreaterThanOrEqual and LessThanOrEqual
+// combined but fails with a single error. min and max must be convertable to decimal numbers and min must not be
+// greater than max or Between panics. value must be convertable to a decimal number. nil is returned unmodified.
+func Between(min, max any) Ensurer {
+	return describe("between", map[string]any{"min": min, "max": max}, between(min, max, false))
+}
+
+// BetweenExclusive returns a Ensurer that fails unless min < value < max. Otherwise it behaves like Between.
+func BetweenExclusive(min, max any) Ensurer {
+	return describe("betweenexclusive", map[string]any{"min": min, "max": max}, between(min, max, true))
+}
+
+func between(min, max any, exclusive bool) Ensurer {
+	dmin, ok := tryDecimal(min)
+	if !ok {
+		panic(fmt.Errorf("%v is not convertable to a decimal number", min))
+	}
+	dmax, ok := tryDecimal(max)
+	if !ok {
+		panic(fmt.Errorf("%v is not convertable to a decimal number", max))
+	}
+	if dmin.GreaterThan(dmax) {
+		panic(fmt.Errorf("min %v is greater than max %v", min, max))
+	}
+
+	code := "not_between"
+	message := fmt.Sprintf("must be between %v and %v", min, max)
+	if exclusive {
+		code = "not_between_exclusive"
+		message = fmt.Sprintf("must be greater than %v and less than %v", min, max)
+	}
+
+	return EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		n, ok := tryDecimal(value)
+		if !ok {
+			return nil, NewError("not_a_number", "not a number", nil)
+		}
+
+		var inRange bool
+		if exclusive {
+			inRange = n.GreaterThan(dmin) && n.LessThan(dmax)
+		} else {
+			inRange = n.GreaterThanOrEqual(dmin) && n.LessThanOrEqual(dmax)
+		}
+		if !inRange {
+			return nil, NewError(code, message, map[string]any{"min": min, "max": max})
 		}
 
 		return value, nil
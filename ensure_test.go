@@ -1,10 +1,15 @@
 package ensure_test
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/ensure"
 	"github.com/jackc/errortree"
 	"github.com/shopspring/decimal"
@@ -25,6 +30,285 @@ func TestRecord(t *testing.T) {
 	assert.Equal(t, "not a valid number", ageErrors[0].Error())
 }
 
+func TestRecordEnsurerThen(t *testing.T) {
+	var lookups int
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", ensure.String(), ensure.Require())
+	}).Then(func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", ensure.MaxLen(32))
+	}).Then(func(r *ensure.RecordWithErrors) {
+		lookups++
+		if r.Get("email") == "taken@example.com" {
+			r.Add("email", errors.New("is already taken"))
+		}
+	})
+
+	_, err := re.Ensure(map[string]any{"email": ""})
+	require.Error(t, err)
+	assert.Equal(t, 0, lookups)
+
+	_, err = re.Ensure(map[string]any{"email": "a-very-long-address-for-a-person@example.com"})
+	require.Error(t, err)
+	assert.Equal(t, 0, lookups)
+
+	_, err = re.Ensure(map[string]any{"email": "taken@example.com"})
+	require.Error(t, err)
+	assert.Equal(t, 1, lookups)
+
+	_, err = re.Ensure(map[string]any{"email": "free@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+}
+
+func TestAddRecordError(t *testing.T) {
+	contact := func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", ensure.NilifyEmpty())
+		r.Ensure("phone", ensure.NilifyEmpty())
+	}
+	requireContact := func(r *ensure.RecordWithErrors) {
+		if r.Get("email") == nil && r.Get("phone") == nil {
+			r.AddRecordError(ensure.NewError("missing_contact", "email or phone is required", nil))
+		}
+	}
+	re := ensure.NewRecordEnsurer(contact).Then(requireContact)
+
+	_, err := re.Ensure(map[string]any{"email": "jack@example.com"})
+	require.NoError(t, err)
+
+	_, err = re.Ensure(map[string]any{"email": ""})
+	require.Error(t, err)
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.Empty(t, recordErrs.FieldErrors()[0].Path)
+	assert.EqualError(t, recordErrs.FieldErrors()[0], "email or phone is required")
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{}), 1)
+
+	err = ensure.Record(ensure.GetterSetterMap{"contact": map[string]any{}}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("contact", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			contact(r)
+			requireContact(r)
+		}))
+	})
+	require.ErrorAs(t, err, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.Equal(t, "contact", recordErrs.FieldErrors()[0].Path.String())
+}
+
+func TestRecordErrorsByField(t *testing.T) {
+	record := ensure.GetterSetterMap{"name": "", "age": "abc", "items": []any{"1", "x", "y"}}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+		r.Ensure("items", ensure.Slice[int32](ensure.Int32()))
+		r.Add("name", errors.New("is reserved"))
+		r.AddRecordError(errors.New("is invalid"))
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Equal(t, []string{"name", "age", "items[1]", "items[2]", ""}, recordErrs.Fields())
+
+	byField := recordErrs.ByField()
+	assert.Len(t, byField, 5)
+	require.Len(t, byField["name"], 2)
+	assert.EqualError(t, byField["name"][1].Err, "is reserved")
+	assert.Len(t, byField["items[1]"], 1)
+	assert.Len(t, byField[""], 1)
+}
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		sentinel error
+	}{
+		{ensure.Require(), "", ensure.ErrRequired},
+		{ensure.Int32(), "abc", ensure.ErrNotANumber},
+		{ensure.MaxLen(3), "abcd", ensure.ErrTooLong},
+		{ensure.MinLen(3), "ab", ensure.ErrTooShort},
+		{ensure.LessThanOrEqual(10), 11, ensure.ErrTooLarge},
+		{ensure.OneOf("a", "b"), "c", ensure.ErrNotAllowed},
+		{ensure.Lower(), 42, ensure.ErrNotAString},
+	}
+
+	for i, tt := range tests {
+		_, err := tt.ensurer.Ensure(tt.value)
+		require.Errorf(t, err, "%d", i)
+		assert.Truef(t, errors.Is(err, tt.sentinel), "%d", i)
+		assert.Falsef(t, errors.Is(err, ensure.ErrTaken), "%d", i)
+	}
+
+	err := ensure.Record(ensure.GetterSetterMap{"name": ""}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.ErrorIs(t, recordErrs.FieldErrors()[0], ensure.ErrRequired)
+	assert.ErrorIs(t, recordErrs.ByField()["name"][0], ensure.ErrRequired)
+}
+
+func TestLabel(t *testing.T) {
+	record := ensure.GetterSetterMap{"dob": "", "name": "", "address": map[string]any{"zip": ""}}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Label("dob", "Date of birth")
+		r.Ensure("dob", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Label("zip", "ZIP code")
+			r.Ensure("zip", ensure.NilifyEmpty(), ensure.Require())
+		}))
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	fieldErrors := recordErrs.FieldErrors()
+	require.Len(t, fieldErrors, 3)
+	assert.Equal(t, "Date of birth", fieldErrors[0].Label)
+	assert.EqualError(t, fieldErrors[0], "Date of birth: cannot be nil or empty")
+	assert.Equal(t, "", fieldErrors[1].Label)
+	assert.EqualError(t, fieldErrors[1], "name: cannot be nil or empty")
+	assert.Equal(t, "address.zip", fieldErrors[2].Path.String())
+	assert.EqualError(t, fieldErrors[2], "ZIP code: cannot be nil or empty")
+}
+
+func TestEnsureAs(t *testing.T) {
+	record := ensure.GetterSetterMap{"firstName": " Jack ", "birthYear": "1980"}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.EnsureAs("firstName", "first_name", ensure.SingleLineString(), ensure.Require())
+		r.EnsureAs("birthYear", "birth_year", ensure.Int32())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{
+		"firstName":  " Jack ",
+		"first_name": "Jack",
+		"birthYear":  "1980",
+		"birth_year": int32(1980),
+	}, record)
+
+	record = ensure.GetterSetterMap{"birthYear": "abc"}
+	err = ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.EnsureAs("birthYear", "birth_year", ensure.Int32())
+	})
+	require.Error(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{"birthYear": "abc"}, record)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"birthYear"}), 1)
+	assert.Len(t, etErr.Get([]any{"birth_year"}), 0)
+}
+
+func TestEnsureEach(t *testing.T) {
+	record := ensure.GetterSetterMap{"first_name": " Jack ", "last_name": "Christensen", "city": "Dallas\nTX"}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.EnsureEach([]string{"first_name", "last_name", "city"}, ensure.SingleLineString(), ensure.MaxLen(8))
+	})
+	require.Error(t, err)
+	assert.Equal(t, "Jack", record["first_name"])
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"first_name"}), 0)
+	assert.Len(t, etErr.Get([]any{"last_name"}), 1)
+	assert.Len(t, etErr.Get([]any{"city"}), 1)
+}
+
+func TestEnsureIf(t *testing.T) {
+	tests := []struct {
+		record    ensure.GetterSetterMap
+		stateErrs int
+		zipErrs   int
+	}{
+		{ensure.GetterSetterMap{"country": "US", "state": "TX", "zip": "75001"}, 0, 0},
+		{ensure.GetterSetterMap{"country": "US", "state": "", "zip": "abc"}, 1, 1},
+		{ensure.GetterSetterMap{"country": " US ", "zip": "75001"}, 1, 0},
+		{ensure.GetterSetterMap{"country": "CA", "zip": "K1A 0B1"}, 0, 0},
+	}
+
+	for i, tt := range tests {
+		errs := ensure.Record(tt.record, func(r *ensure.RecordWithErrors) {
+			r.Ensure("country", ensure.SingleLineString())
+			r.RequireIf(ensure.FieldEquals("country", "US"), "state")
+			r.EnsureIf(ensure.FieldEquals("country", "US"), "zip", ensure.Int32())
+		})
+
+		if tt.stateErrs == 0 && tt.zipErrs == 0 {
+			assert.NoErrorf(t, errs, "%d", i)
+			continue
+		}
+
+		var etErr *errortree.Node
+		require.ErrorAsf(t, errs, &etErr, "%d", i)
+		assert.Lenf(t, etErr.Get([]any{"state"}), tt.stateErrs, "%d", i)
+		assert.Lenf(t, etErr.Get([]any{"zip"}), tt.zipErrs, "%d", i)
+	}
+}
+
+func TestNested(t *testing.T) {
+	record := ensure.GetterSetterMap{
+		"name":    "Adam",
+		"address": map[string]any{"city": " Dallas ", "zip": "abc"},
+	}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require())
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("city", ensure.SingleLineString())
+			r.Ensure("zip", ensure.Int32())
+		}))
+	})
+	require.Error(t, errs)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, errs, &etErr)
+	zipErrors := etErr.Get([]any{"address", "zip"})
+	require.Len(t, zipErrors, 1)
+	assert.Equal(t, "not a valid number", zipErrors[0].Error())
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	fieldErrs := recordErrs.FieldErrors()
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, ensure.Path{"address", "zip"}, fieldErrs[0].Path)
+	assert.Equal(t, "address.zip: not a valid number", fieldErrs[0].Error())
+	assert.Equal(t, "Dallas", record["address"].(map[string]any)["city"])
+
+	value, err := ensure.Nested(func(r *ensure.RecordWithErrors) {}).Ensure(nil)
+	assert.Nil(t, value)
+	assert.NoError(t, err)
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		code    string
+		params  map[string]any
+	}{
+		{ensure.Int64(), "abc", "not_a_number", nil},
+		{ensure.Int32(), "3000000000", "too_large", map[string]any{"max": int32(2147483647)}},
+		{ensure.Bool(), "abc", "not_a_boolean", nil},
+		{ensure.UUID(), "abc", "not_a_uuid", nil},
+		{ensure.Require(), "", "required", nil},
+		{ensure.MaxLen(3), "abcd", "too_long", map[string]any{"max": 3}},
+		{ensure.MinLen(3), "ab", "too_short", map[string]any{"min": 3}},
+		{ensure.AllowStrings("foo"), "bar", "not_allowed", nil},
+		{ensure.LessThanOrEqual(10), 11, "too_large", map[string]any{"max": 10}},
+	}
+
+	for i, tt := range tests {
+		_, err := tt.ensurer.Ensure(tt.value)
+		var ensureErr *ensure.Error
+		require.ErrorAsf(t, err, &ensureErr, "%d", i)
+		assert.Equalf(t, tt.code, ensureErr.Code, "%d", i)
+		assert.Equalf(t, tt.params, ensureErr.Params, "%d", i)
+	}
+}
+
 func TestNotNil(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -85,6 +369,87 @@ func TestInt64(t *testing.T) {
 	}
 }
 
+func TestIntWidths(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Int(), "42", 42, true},
+		{ensure.Int(), float64(-7), -7, true},
+		{ensure.Int(), "abc", nil, false},
+		{ensure.Int16(), "32767", int16(32767), true},
+		{ensure.Int16(), "32768", nil, false},
+		{ensure.Int16(), -32768, int16(-32768), true},
+		{ensure.Int16(), -32769, nil, false},
+		{ensure.Int8(), " 127 ", int8(127), true},
+		{ensure.Int8(), 128, nil, false},
+		{ensure.Int8(), "", nil, true},
+		{ensure.Uint64(), "18446744073709551615", uint64(18446744073709551615), true},
+		{ensure.Uint64(), "18446744073709551616", nil, false},
+		{ensure.Uint64(), uint64(18446744073709551615), uint64(18446744073709551615), true},
+		{ensure.Uint64(), -1, nil, false},
+		{ensure.Uint64(), "-1", nil, false},
+		{ensure.Uint64(), float64(3), uint64(3), true},
+		{ensure.Uint64(), float64(3.5), nil, false},
+		{ensure.Uint32(), "4294967295", uint32(4294967295), true},
+		{ensure.Uint32(), int64(4294967296), nil, false},
+		{ensure.Uint16(), 65535, uint16(65535), true},
+		{ensure.Uint16(), 65536, nil, false},
+		{ensure.Uint8(), "255", uint8(255), true},
+		{ensure.Uint8(), "256", nil, false},
+		{ensure.Uint8(), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	_, err := ensure.Uint8().Ensure(-1)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "too_small", ensureErr.Code)
+	assert.Equal(t, map[string]any{"min": uint64(0)}, ensureErr.Params)
+
+	_, err = ensure.Uint8().Ensure(300)
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "too_large", ensureErr.Code)
+	assert.Equal(t, map[string]any{"max": uint8(255)}, ensureErr.Params)
+}
+
+func TestBigInt(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"123456789012345678901234567890", huge, true},
+		{" -42 ", big.NewInt(-42), true},
+		{42, big.NewInt(42), true},
+		{uint64(18446744073709551615), new(big.Int).SetUint64(18446744073709551615), true},
+		{float64(1e20), new(big.Int).Mul(big.NewInt(1e10), big.NewInt(1e10)), true},
+		{float64(1.5), nil, false},
+		{decimal.RequireFromString("100"), big.NewInt(100), true},
+		{decimal.RequireFromString("100.5"), nil, false},
+		{huge, huge, true},
+		{"10.5", nil, false},
+		{"abc", nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.BigInt().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestFloat64(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -157,6 +522,29 @@ func TestBool(t *testing.T) {
 	}
 }
 
+func TestBoolNullable(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{true, true, true},
+		{false, false, true},
+		{"false", false, true},
+		{"null", nil, true},
+		{" Unknown ", nil, true},
+		{"abc", nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.BoolNullable().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestTime(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -185,6 +573,86 @@ func TestTime(t *testing.T) {
 	}
 }
 
+func TestTimeInLocation(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	value, err := ensure.Time("2006-01-02 15:04:05").InLocation(chicago).Ensure("2023-06-24 20:41:50")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 6, 24, 20, 41, 50, 0, chicago), value)
+
+	value, err = ensure.Time("2006-01-02 15:04:05").InLocation(chicago).ConvertTo(time.UTC).Ensure("2023-06-24 20:41:50")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 6, 25, 1, 41, 50, 0, time.UTC), value)
+
+	value, err = ensure.Time(time.RFC3339).InLocation(chicago).Ensure("2023-06-24T20:41:50Z")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2023, 6, 24, 20, 41, 50, 0, time.UTC).Equal(value.(time.Time)))
+}
+
+func TestTimeAcceptUnixSeconds(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{int64(1687639310), time.Date(2023, 6, 24, 20, 41, 50, 0, time.UTC), true},
+		{1687639310.5, time.Date(2023, 6, 24, 20, 41, 50, 500000000, time.UTC), true},
+		{"1687639310", time.Date(2023, 6, 24, 20, 41, 50, 0, time.UTC), true},
+		{"2023-06-24", time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{"foo", nil, false},
+		{1e30, nil, false},
+		{-1e30, nil, false},
+		{"1e30", nil, false},
+		{math.NaN(), nil, false},
+		{math.Inf(1), nil, false},
+		{math.Inf(-1), nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Time("2006-01-02").AcceptUnixSeconds().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+		if !tt.success {
+			var ensureErr *ensure.Error
+			require.ErrorAsf(t, err, &ensureErr, "%d", i)
+			assert.Equalf(t, "not_a_time", ensureErr.Code, "%d", i)
+		}
+	}
+
+	_, err := ensure.Time("2006-01-02").Ensure(int64(1687639310))
+	assert.Error(t, err)
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		value    any
+		formats  []string
+		expected any
+		success  bool
+	}{
+		{"2023-06-24", nil, time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{" 2023-06-24 ", nil, time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{"06/24/2023", []string{"2006-01-02", "01/02/2006"}, time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2023, 6, 24, 0, 0, 0, 0, time.Local), nil, time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2023, 6, 24, 20, 41, 50, 0, time.UTC), nil, nil, false},
+		{"2023-06-24 20:41:50", []string{"2006-01-02 15:04:05"}, nil, false},
+		{"2023-06-24 00:00:00", []string{"2006-01-02 15:04:05"}, time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC), true},
+		{"2023-02-30", nil, nil, false},
+		{"foo", nil, nil, false},
+		{42, nil, nil, false},
+		{nil, nil, nil, true},
+		{"", nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Date(tt.formats...).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestDecimal(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -208,6 +676,30 @@ func TestDecimal(t *testing.T) {
 	}
 }
 
+func TestUUID(t *testing.T) {
+	id := uuid.Must(uuid.FromString("a5ccbf71-0d1e-4f4b-8d8a-3e1f7c2d9b10"))
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"a5ccbf71-0d1e-4f4b-8d8a-3e1f7c2d9b10", id, true},
+		{" a5ccbf71-0d1e-4f4b-8d8a-3e1f7c2d9b10 ", id, true},
+		{id.Bytes(), id, true},
+		{nil, nil, true},
+		{"", nil, true},
+		{"abc", nil, false},
+		{[]byte{1, 2, 3}, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.UUID().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestSliceRecord(t *testing.T) {
 	elementEnsurer := ensure.NewRecordEnsurer(func(record *ensure.RecordWithErrors) {
 		record.Ensure("n", ensure.Int32(), ensure.Require())
@@ -243,6 +735,30 @@ func TestSliceRecord(t *testing.T) {
 	}
 }
 
+func TestSliceRecordErrorPaths(t *testing.T) {
+	record := ensure.GetterSetterMap{
+		"items": []any{
+			map[string]any{"price": "1.50"},
+			map[string]any{"price": "abc"},
+			"foo",
+		},
+	}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("items", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("price", ensure.Decimal())
+		})))
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	fieldErrs := recordErrs.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, ensure.Path{"items", 1, "price"}, fieldErrs[0].Path)
+	assert.Equal(t, "items[1].price", fieldErrs[0].Path.String())
+	assert.Equal(t, ensure.Path{"items", 2}, fieldErrs[1].Path)
+	assert.Equal(t, "items[2]: not a record", fieldErrs[1].Error())
+}
+
 func TestSliceInt32(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -264,6 +780,42 @@ func TestSliceInt32(t *testing.T) {
 	}
 }
 
+func TestSliceErrorLimits(t *testing.T) {
+	var calls int
+	countingInt32 := ensure.EnsurerFunc(func(value any) (any, error) {
+		calls++
+		return ensure.Int32().Ensure(value)
+	})
+	value := []any{"1", "a", "b", "4", "c"}
+
+	tests := []struct {
+		options []ensure.SliceOption
+		errors  int
+		calls   int
+	}{
+		{nil, 3, 5},
+		{[]ensure.SliceOption{ensure.FailFast()}, 1, 2},
+		{[]ensure.SliceOption{ensure.MaxErrors(2)}, 2, 3},
+		{[]ensure.SliceOption{ensure.MaxErrors(10)}, 3, 5},
+	}
+
+	for i, tt := range tests {
+		calls = 0
+		record := ensure.GetterSetterMap{"ids": value}
+		errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+			r.Ensure("ids", ensure.Slice[int32](countingInt32, tt.options...))
+		})
+
+		var recordErrs *ensure.RecordErrors
+		require.ErrorAsf(t, errs, &recordErrs, "%d", i)
+		assert.Lenf(t, recordErrs.FieldErrors(), tt.errors, "%d", i)
+		assert.Equalf(t, tt.calls, calls, "%d", i)
+		assert.Equalf(t, "ids[1]", recordErrs.FieldErrors()[0].Path.String(), "%d", i)
+	}
+
+	assert.Panics(t, func() { ensure.MaxErrors(0) })
+}
+
 func TestSliceString(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -308,6 +860,151 @@ func TestSingleLineString(t *testing.T) {
 	}
 }
 
+func TestWhenUnless(t *testing.T) {
+	isHTTP := func(v any) bool {
+		s, _ := v.(string)
+		return strings.HasPrefix(s, "http")
+	}
+
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.When(isHTTP, ensure.URL("https")), "https://example.com", "https://example.com", true},
+		{ensure.When(isHTTP, ensure.URL("https")), "http://example.com", nil, false},
+		{ensure.When(isHTTP, ensure.URL("https")), "mailto:jack@example.com", "mailto:jack@example.com", true},
+		{ensure.When(isHTTP, ensure.URL("https")), nil, nil, true},
+		{ensure.When(isHTTP, ensure.Upper(), ensure.MaxLen(5)), "httpx", "HTTPX", true},
+		{ensure.When(isHTTP, ensure.Upper(), ensure.MaxLen(5)), "https", "HTTPS", true},
+		{ensure.When(isHTTP, ensure.Upper(), ensure.MaxLen(5)), "https:", nil, false},
+		{ensure.Unless(isHTTP, ensure.Lower()), "ABC", "abc", true},
+		{ensure.Unless(isHTTP, ensure.Lower()), "httpABC", "httpABC", true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	title := ensure.All(ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(10))
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"  Hello  ", "Hello", true},
+		{"", nil, false},
+		{nil, nil, false},
+		{"Hello, world", nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := title.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	record := ensure.GetterSetterMap{"title": " Hello ", "subtitle": "Hello, world"}
+	err := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("title", title)
+		r.Ensure("subtitle", title)
+	})
+	require.Error(t, err)
+	assert.Equal(t, "Hello", record["title"])
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"title"}), 0)
+	assert.Len(t, etErr.Get([]any{"subtitle"}), 1)
+}
+
+func TestAnyOf(t *testing.T) {
+	id := ensure.AnyOf(ensure.UUID(), ensure.Int64())
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"a5ccbf71-0d1e-4f4b-8d8a-3e1f7c2d9b10", uuid.Must(uuid.FromString("a5ccbf71-0d1e-4f4b-8d8a-3e1f7c2d9b10")), true},
+		{"42", int64(42), true},
+		{int32(7), int64(7), true},
+		{"abc", nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := id.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	_, err := ensure.AnyOf().Ensure("abc")
+	require.Error(t, err)
+}
+
+func TestCollapseSpaces(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"a    b", "a b", true},
+		{" a \t\t b\u00a0 c ", "a b c", true},
+		{"abc", "abc", true},
+		{"", "", true},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.CollapseSpaces().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	value, err := ensure.SingleLineString().Ensure("a\n\n  b")
+	require.NoError(t, err)
+	value, err = ensure.CollapseSpaces().Ensure(value)
+	require.NoError(t, err)
+	assert.Equal(t, "a b", value)
+}
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"curl/8.0", "curl/8.0", true},
+		{" curl/8.0\r\n", "curl/8.0", true},
+		{"agent\x00\xffname", "agent name", true},
+		{"Mozilla/5.0 (X11; Linux x86_64)", "Mozilla/5.", true},
+		{"Mozilla/   x", "Mozilla/", true},
+		{"Mozilla/ é", "Mozilla/", true},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.UserAgent(10).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	value, err := ensure.UserAgent(0).Ensure("curl/8.0")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	assert.Panics(t, func() { ensure.UserAgent(-1) })
+}
+
 func TestNilifyEmpty(t *testing.T) {
 	type otherString string
 
@@ -391,6 +1088,38 @@ func TestMaxLen(t *testing.T) {
 	}
 }
 
+func TestMaxInputBytes(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"12345678", "12345678", true},
+		{"123456789", nil, false},
+		{"éééé", "éééé", true},
+		{"ééééé", nil, false},
+		{[]byte("123456789"), nil, false},
+		{int64(123456789), int64(123456789), true},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.MaxInputBytes(8).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	record := ensure.GetterSetterMap{"amount": strings.Repeat("9", 100)}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("amount", ensure.MaxInputBytes(64), ensure.Decimal())
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, recordErrs.FieldErrors()[0], &ensureErr)
+	assert.Equal(t, map[string]any{"max_bytes": 64}, ensureErr.Params)
+}
+
 func TestAllowStrings(t *testing.T) {
 	tests := []struct {
 		value         any
@@ -449,6 +1178,92 @@ func TestExcludeStrings(t *testing.T) {
 	}
 }
 
+type testStatus string
+
+func TestStringConstraints(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{ensure.HasPrefix("INV-"), "INV-001", "INV-001", ""},
+		{ensure.HasPrefix("INV-"), "PO-001", nil, `must start with "INV-"`},
+		{ensure.HasSuffix(".pdf"), "report.pdf", "report.pdf", ""},
+		{ensure.HasSuffix(".pdf"), "report.doc", nil, `must end with ".pdf"`},
+		{ensure.Contains("@"), "jack@example.com", "jack@example.com", ""},
+		{ensure.Contains("@"), "jack", nil, `must contain "@"`},
+		{ensure.NotContains(".."), "a/b", "a/b", ""},
+		{ensure.NotContains(".."), "../etc", nil, `must not contain ".."`},
+		{ensure.HasPrefix("x"), 42, nil, "not a string"},
+		{ensure.HasPrefix("x"), nil, nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		if tt.errMsg == "" {
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+		}
+	}
+
+	_, err := ensure.HasSuffix(".pdf").Ensure("x")
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "missing_suffix", ensureErr.Code)
+	assert.Equal(t, map[string]any{"suffix": ".pdf"}, ensureErr.Params)
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		value    any
+		x        any
+		expected any
+		success  bool
+	}{
+		{"yes", "yes", "yes", true},
+		{"no", "yes", nil, false},
+		{int32(3), int32(3), int32(3), true},
+		{int64(3), int32(3), nil, false},
+		{nil, "yes", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Equal(tt.x).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.OneOf(200, 404), 200, 200, true},
+		{ensure.OneOf(200, 404), 500, nil, false},
+		{ensure.OneOf(200, 404), int32(200), nil, false},
+		{ensure.OneOf[testStatus]("active", "archived"), testStatus("active"), testStatus("active"), true},
+		{ensure.OneOf[testStatus]("active", "archived"), "active", nil, false},
+		{ensure.OneOf(200, 404), nil, nil, true},
+		{ensure.NotOneOf(500, 503), 200, 200, true},
+		{ensure.NotOneOf(500, 503), 503, nil, false},
+		{ensure.NotOneOf[testStatus]("deleted"), testStatus("deleted"), nil, false},
+		{ensure.NotOneOf(500, 503), "200", nil, false},
+		{ensure.NotOneOf(500, 503), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestLessThan(t *testing.T) {
 	tests := []struct {
 		value      any
@@ -556,6 +1371,45 @@ func TestGreaterThanOrEqual(t *testing.T) {
 	}
 }
 
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		ensurer    ensure.Ensurer
+		value      any
+		expected   any
+		errMatcher *regexp.Regexp
+	}{
+		{ensure.Between(1, 100), 1, 1, nil},
+		{ensure.Between(1, 100), "100", "100", nil},
+		{ensure.Between(1, 100), decimal.NewFromInt(50), decimal.NewFromInt(50), nil},
+		{ensure.Between(1, 100), 0, nil, regexp.MustCompile(`^must be between 1 and 100$`)},
+		{ensure.Between(1, 100), 100.5, nil, regexp.MustCompile(`^must be between 1 and 100$`)},
+		{ensure.BetweenExclusive(0, 1), 0.5, 0.5, nil},
+		{ensure.BetweenExclusive(0, 1), 0, nil, regexp.MustCompile(`^must be greater than 0 and less than 1$`)},
+		{ensure.BetweenExclusive(0, 1), 1, nil, regexp.MustCompile(`^must be greater than 0 and less than 1$`)},
+		{ensure.Between(1, 100), "abc", nil, regexp.MustCompile(`not a number`)},
+		{ensure.Between(1, 100), nil, nil, nil},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		if tt.errMatcher == nil {
+			assert.NoError(t, err, "%d", i)
+		} else {
+			assert.Regexpf(t, tt.errMatcher, err.Error(), "%d", i)
+		}
+	}
+
+	_, err := ensure.Between(1, 100).Ensure(101)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "not_between", ensureErr.Code)
+	assert.Equal(t, map[string]any{"min": 1, "max": 100}, ensureErr.Params)
+
+	assert.Panics(t, func() { ensure.Between(10, 1) })
+	assert.Panics(t, func() { ensure.BetweenExclusive("abc", 1) })
+}
+
 func BenchmarkRecordEnsurerEnsure(b *testing.B) {
 	recordEnsurer := ensure.NewRecordEnsurer(func(record *ensure.RecordWithErrors) {
 		record.Ensure("name", ensure.SingleLineString(), ensure.Require())
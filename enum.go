@@ -0,0 +1,82 @@
+package ensure
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Enum returns a Ensurer that converts value to the typed enum value T. value may already be a T or it may be a string
+// or number matching the underlying value of one of values. e.g. with type Status string, Enum[Status]("active",
+// "archived") converts "active" to Status("active"). Space is trimmed from both sides of strings. If value is nil or a
+// blank string nil is returned. If value does not match one of values an error is returned.
+func Enum[T ~string | ~int | ~int8 | ~int16 | ~int32 | ~int64](values ...T) Ensurer {
+	m := make(map[string]T, len(values))
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.String {
+			m[rv.String()] = v
+		} else {
+			m[strconv.FormatInt(rv.Int(), 10)] = v
+		}
+	}
+
+	return describe("enum", map[string]any{"allowed": values}, enum(m, values))
+}
+
+// EnumMap returns a Ensurer that converts value to the typed enum value T by looking up value in m. This allows the
+// input names of an enum to differ from its Go values. e.g. EnumMap(map[string]Priority{"low": PriorityLow, "high":
+// PriorityHigh}). value may also already be one of the values of m. If value is nil or a blank string nil is returned.
+// If value is not found an error is returned.
+func EnumMap[T comparable](m map[string]T) Ensurer {
+	values := make([]T, 0, len(m))
+	names := make([]string, 0, len(m))
+	for name, v := range m {
+		values = append(values, v)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return describe("enum", map[string]any{"allowed": names}, enum(m, values))
+}
+
+func enum[T comparable](m map[string]T, values []T) Ensurer {
+	allowed := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		allowed[v] = struct{}{}
+	}
+
+	return EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		if v, ok := value.(T); ok {
+			if _, ok := allowed[v]; ok {
+				return v, nil
+			}
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		var key string
+		switch value := value.(type) {
+		case string:
+			key = value
+		default:
+			n, err := convertInt64(value)
+			if err != nil {
+				return nil, NewError("not_allowed", "not allowed value", nil)
+			}
+			key = strconv.FormatInt(n, 10)
+		}
+
+		v, ok := m[key]
+		if !ok {
+			return nil, NewError("not_allowed", "not allowed value", nil)
+		}
+
+		return v, nil
+	})
+}
@@ -0,0 +1,47 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPriority int
+
+const (
+	testPriorityLow  testPriority = 1
+	testPriorityHigh testPriority = 2
+)
+
+func TestEnum(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Enum[testStatus]("active", "archived"), "active", testStatus("active"), true},
+		{ensure.Enum[testStatus]("active", "archived"), " archived ", testStatus("archived"), true},
+		{ensure.Enum[testStatus]("active", "archived"), testStatus("active"), testStatus("active"), true},
+		{ensure.Enum[testStatus]("active", "archived"), "deleted", nil, false},
+		{ensure.Enum[testStatus]("active", "archived"), testStatus("deleted"), nil, false},
+		{ensure.Enum[testStatus]("active", "archived"), "", nil, true},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), "2", testPriorityHigh, true},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), int64(1), testPriorityLow, true},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), float64(1), testPriorityLow, true},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), testPriorityHigh, testPriorityHigh, true},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), 3, nil, false},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), "high", nil, false},
+		{ensure.Enum(testPriorityLow, testPriorityHigh), nil, nil, true},
+		{ensure.EnumMap(map[string]testPriority{"low": testPriorityLow, "high": testPriorityHigh}), "high", testPriorityHigh, true},
+		{ensure.EnumMap(map[string]testPriority{"low": testPriorityLow, "high": testPriorityHigh}), testPriorityLow, testPriorityLow, true},
+		{ensure.EnumMap(map[string]testPriority{"low": testPriorityLow, "high": testPriorityHigh}), "medium", nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
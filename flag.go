@@ -0,0 +1,51 @@
+package ensure
+
+import (
+	"flag"
+)
+
+// Flags adapts a parsed flag.FlagSet to a GetterSetter so command line flags can be ensured with the same ensurers as
+// any other record. Get returns the flag's value, which is its default if it was not set on the command line, or nil
+// if there is no flag with that name. Values that implement flag.Getter, such as those of the flags defined by
+// flag.Int and flag.Bool, are returned with their Go type; any other value is returned as its string. Set stores the
+// ensured value in Flags without modifying the FlagSet.
+type Flags struct {
+	fs     *flag.FlagSet
+	values map[string]any
+}
+
+// NewFlags returns a Flags for fs.
+func NewFlags(fs *flag.FlagSet) *Flags {
+	return &Flags{fs: fs, values: map[string]any{}}
+}
+
+func (f *Flags) Get(name string) any {
+	if value, ok := f.values[name]; ok {
+		return value
+	}
+
+	fl := f.fs.Lookup(name)
+	if fl == nil {
+		return nil
+	}
+
+	if getter, ok := fl.Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return fl.Value.String()
+}
+
+func (f *Flags) Set(name string, value any) {
+	f.values[name] = value
+}
+
+// IsSet reports whether the flag name was set on the command line.
+func (f *Flags) IsSet(name string) bool {
+	isSet := false
+	f.fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == name {
+			isSet = true
+		}
+	})
+	return isSet
+}
@@ -0,0 +1,54 @@
+package ensure_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Int("port", 8080, "")
+	fs.String("email", "", "")
+	fs.String("since", "", "")
+	fs.Bool("verbose", false, "")
+	fs.Duration("timeout", time.Second, "")
+	err := fs.Parse([]string{"-email", " Jack@Example.com ", "-since", "2024-01-02", "-verbose"})
+	require.NoError(t, err)
+
+	flags := ensure.NewFlags(fs)
+	assert.Equal(t, 8080, flags.Get("port"))
+	assert.Equal(t, true, flags.Get("verbose"))
+	assert.Equal(t, time.Second, flags.Get("timeout"))
+	assert.Nil(t, flags.Get("missing"))
+	assert.True(t, flags.IsSet("email"))
+	assert.False(t, flags.IsSet("port"))
+
+	err = ensure.Record(flags, func(r *ensure.RecordWithErrors) {
+		r.Ensure("port", ensure.Int32(), ensure.Between(1, 65535))
+		r.Ensure("email", ensure.SingleLineString(), ensure.Lower(), ensure.Require())
+		r.Ensure("since", ensure.Date())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(8080), flags.Get("port"))
+	assert.Equal(t, "jack@example.com", flags.Get("email"))
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), flags.Get("since"))
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("port", 0, "")
+	require.NoError(t, fs.Parse([]string{"-port", "70000"}))
+
+	err = ensure.Record(ensure.NewFlags(fs), func(r *ensure.RecordWithErrors) {
+		r.Ensure("port", ensure.Int32(), ensure.Between(1, 65535))
+	})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"port"}), 1)
+}
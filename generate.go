@@ -0,0 +1,581 @@
+package ensure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/shopspring/decimal"
+)
+
+// generatorMaxAttempts is the number of times a Generator regenerates the fields of a record that fail before giving
+// up.
+const generatorMaxAttempts = 100
+
+// Generator produces random example records for a RecordEnsurer for property-based tests and documentation. Records
+// are generated from the JSONSchema of the RecordEnsurer and then checked by ensuring a copy, so a record returned by
+// Valid is always accepted and one returned by Invalid is always rejected. Fields whose ensurers do not describe
+// themselves are generated as strings, so a RecordEnsurer with such fields may fail to generate a valid record. A
+// Generator is not safe for concurrent use.
+type Generator struct {
+	re     *RecordEnsurer
+	schema map[string]any
+	rand   *rand.Rand
+
+	// example is true to generate representative values instead of random ones.
+	example bool
+}
+
+// NewGenerator returns a Generator for re. The same seed generates the same records.
+func NewGenerator(re *RecordEnsurer, seed int64) *Generator {
+	return &Generator{re: re, schema: re.objectSchema(), rand: rand.New(rand.NewSource(seed))}
+}
+
+// Example returns a representative record that re accepts for documentation such as an example request of an API. It
+// is derived from the rules of re: every field is included with the first allowed value, a value of its format, a short
+// string, or a small number within its bounds. Slices and maps have the fewest elements allowed. Unlike the records of
+// a Generator it is the same every time. Fields that fail with these values, such as those with ensurers that do not
+// describe themselves, are generated at random until re accepts the record. If that fails the record is returned as is.
+func (re *RecordEnsurer) Example() map[string]any {
+	g := NewGenerator(re, 1)
+	g.example = true
+	record := g.object(g.schema)
+	g.example = false
+
+	if valid, err := g.valid(record); err == nil {
+		return valid
+	}
+	return record
+}
+
+// Valid returns a random record that re accepts. Optional fields are included at random. Values are in the form they
+// are received in such as strings for UUIDs and dates. It returns an error if no valid record was found.
+func (g *Generator) Valid() (map[string]any, error) {
+	return g.valid(g.object(g.schema))
+}
+
+// valid regenerates the fields of record that fail until re accepts it.
+func (g *Generator) valid(record map[string]any) (map[string]any, error) {
+	properties, _ := g.schema["properties"].(map[string]any)
+
+	var err error
+	for attempt := 0; attempt < generatorMaxAttempts; attempt++ {
+		err = g.check(record)
+		if err == nil {
+			return record, nil
+		}
+
+		var recordErrs *RecordErrors
+		if !errors.As(err, &recordErrs) {
+			return nil, err
+		}
+
+		for _, fe := range recordErrs.FieldErrors() {
+			field, ok := pathField(fe.Path)
+			if !ok {
+				record = g.object(g.schema)
+				break
+			}
+			if schema, ok := properties[field].(map[string]any); ok {
+				record[field] = g.value(schema)
+			} else {
+				delete(record, field)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("could not generate a valid record in %d attempts: %w", generatorMaxAttempts, err)
+}
+
+// Invalid returns a random record that re rejects and the field that makes it invalid. It is a valid record with one
+// field changed to violate a constraint such as removing a required field or exceeding a maximum length.
+func (g *Generator) Invalid() (record map[string]any, field string, err error) {
+	valid, err := g.Valid()
+	if err != nil {
+		return nil, "", err
+	}
+
+	violations := g.violations()
+	g.rand.Shuffle(len(violations), func(i, j int) { violations[i], violations[j] = violations[j], violations[i] })
+
+	for _, v := range violations {
+		record := cloneValue(valid).(map[string]any)
+		v.apply(record)
+
+		var recordErrs *RecordErrors
+		if errors.As(g.check(record), &recordErrs) {
+			for _, fe := range recordErrs.FieldErrors() {
+				if f, ok := pathField(fe.Path); ok && f == v.field {
+					return record, v.field, nil
+				}
+			}
+		}
+	}
+
+	return nil, "", errors.New("could not generate an invalid record: no constraint to violate")
+}
+
+// check ensures a copy of record so record keeps the form it was generated in.
+func (g *Generator) check(record map[string]any) error {
+	_, err := g.re.Ensure(cloneValue(record))
+	return err
+}
+
+// pathField returns the top-level field of path.
+func pathField(path Path) (string, bool) {
+	if len(path) == 0 {
+		return "", false
+	}
+	field, ok := path[0].(string)
+	return field, ok
+}
+
+// object generates an object with the properties of schema.
+func (g *Generator) object(schema map[string]any) map[string]any {
+	record := make(map[string]any)
+
+	properties, _ := schema["properties"].(map[string]any)
+	required := make(map[string]bool)
+	requiredFields, _ := schema["required"].([]string)
+	for _, field := range requiredFields {
+		required[field] = true
+	}
+
+	for _, field := range sortedKeys(properties) {
+		if required[field] || g.example || g.rand.Intn(2) == 0 {
+			record[field] = g.value(properties[field].(map[string]any))
+		}
+	}
+
+	return record
+}
+
+// value generates a value that satisfies schema.
+func (g *Generator) value(schema map[string]any) any {
+	if anyOf, ok := schema["anyOf"].([]any); ok && len(anyOf) > 0 {
+		if g.example {
+			return g.value(anyOf[0].(map[string]any))
+		}
+		return g.value(anyOf[g.rand.Intn(len(anyOf))].(map[string]any))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		var values []any
+		for _, v := range enum {
+			if v != nil {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 && g.example {
+			return values[0]
+		}
+		if len(values) > 0 {
+			return values[g.rand.Intn(len(values))]
+		}
+	}
+
+	switch schemaType(schema) {
+	case "boolean":
+		return g.example || g.rand.Intn(2) == 0
+	case "integer":
+		return g.integer(schema)
+	case "number":
+		return g.number(schema)
+	case "array":
+		min, max := schemaCount(schema, "minItems", "maxItems")
+		items, _ := schema["items"].(map[string]any)
+		elements := make([]any, g.count(min, max))
+		for i := range elements {
+			elements[i] = g.value(items)
+		}
+		return elements
+	case "object":
+		if _, ok := schema["properties"]; ok {
+			return g.object(schema)
+		}
+		min, max := schemaCount(schema, "minProperties", "maxProperties")
+		values, _ := schema["additionalProperties"].(map[string]any)
+		m := make(map[string]any)
+		for i := g.count(min, max); i > 0; i-- {
+			m[fmt.Sprintf("key%d", i)] = g.value(values)
+		}
+		return m
+	default:
+		return g.string(schema)
+	}
+}
+
+// schemaType returns the type of schema without "null".
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []string:
+		return t[0]
+	}
+	return ""
+}
+
+// schemaCount returns the bounds of a count such as minItems and maxItems. Unbounded counts are kept small.
+func schemaCount(schema map[string]any, minKeyword, maxKeyword string) (min, max int) {
+	min, _ = schema[minKeyword].(int)
+	max, ok := schema[maxKeyword].(int)
+	if !ok || max > min+3 {
+		max = min + 3
+	}
+	if min == 0 && max > 0 {
+		min = 1
+	}
+	return min, max
+}
+
+// count returns a count between min and max. An example has the fewest.
+func (g *Generator) count(min, max int) int {
+	if g.example {
+		return min
+	}
+	return min + g.rand.Intn(max-min+1)
+}
+
+// schemaBounds returns the inclusive range of numbers allowed by schema. step is the amount an exclusive bound is moved
+// inward. Unbounded ranges default to 0 to 100 from the bound that is given.
+func schemaBounds(schema map[string]any, step decimal.Decimal) (lo, hi decimal.Decimal) {
+	bound := func(keyword string) (decimal.Decimal, bool) {
+		n, ok := schema[keyword].(json.Number)
+		if !ok {
+			return decimal.Decimal{}, false
+		}
+		d, err := decimal.NewFromString(string(n))
+		return d, err == nil
+	}
+
+	hundred := decimal.NewFromInt(100)
+	min, hasMin := bound("minimum")
+	if d, ok := bound("exclusiveMinimum"); ok && (!hasMin || d.GreaterThanOrEqual(min)) {
+		min, hasMin = d.Add(step), true
+	}
+	max, hasMax := bound("maximum")
+	if d, ok := bound("exclusiveMaximum"); ok && (!hasMax || d.LessThanOrEqual(max)) {
+		max, hasMax = d.Sub(step), true
+	}
+
+	switch {
+	case hasMin && hasMax:
+		return min, max
+	case hasMin:
+		return min, min.Add(hundred)
+	case hasMax:
+		return max.Sub(hundred), max
+	default:
+		return decimal.Zero, hundred
+	}
+}
+
+// integer generates an integer within the bounds of schema. It is an int64 if it fits and otherwise a json.Number.
+func (g *Generator) integer(schema map[string]any) any {
+	lo, hi := schemaBounds(schema, decimal.NewFromInt(1))
+	lo, hi = lo.Ceil(), hi.Floor()
+	if hi.LessThan(lo) {
+		return lo.IntPart()
+	}
+	if g.example {
+		return decimal.Min(decimal.Max(decimal.NewFromInt(1), lo), hi).IntPart()
+	}
+
+	span := hi.Sub(lo)
+	if span.GreaterThan(decimal.NewFromInt(1000)) {
+		span = decimal.NewFromInt(1000)
+	}
+	n := lo.Add(decimal.NewFromInt(g.rand.Int63n(span.IntPart() + 1)))
+	if n.Equal(decimal.NewFromInt(n.IntPart())) {
+		return n.IntPart()
+	}
+	return json.Number(n.String())
+}
+
+// number generates a number with two decimal places within the bounds of schema.
+func (g *Generator) number(schema map[string]any) any {
+	lo, hi := schemaBounds(schema, decimal.New(1, -2))
+	if g.example {
+		n, _ := decimal.Min(decimal.Max(decimal.NewFromFloat(1.5), lo), hi).Float64()
+		return n
+	}
+	f := lo.Add(hi.Sub(lo).Mul(decimal.NewFromFloat(g.rand.Float64()))).Round(2)
+	if f.LessThan(lo) || f.GreaterThan(hi) {
+		f = lo
+	}
+	n, _ := f.Float64()
+	return n
+}
+
+// string generates a string that has the format, length, and patterns of schema.
+func (g *Generator) string(schema map[string]any) string {
+	switch schema["format"] {
+	case "uuid":
+		var u uuid.UUID
+		g.rand.Read(u[:])
+		u.SetVersion(uuid.V4)
+		u.SetVariant(uuid.VariantRFC4122)
+		return u.String()
+	case "date":
+		return g.time().Format("2006-01-02")
+	case "date-time":
+		return g.time().Format(time.RFC3339)
+	case "uri":
+		return "https://example.com/" + g.letters(1+g.rand.Intn(8))
+	}
+
+	if schema["contentMediaType"] == "application/json" {
+		if g.example {
+			return "{}"
+		}
+		return []string{"{}", "[]"}[g.rand.Intn(2)]
+	}
+
+	min, _ := schema["minLength"].(int)
+	max, ok := schema["maxLength"].(int)
+	if !ok || max > min+12 {
+		max = min + 12
+	}
+	if min == 0 && max > 0 {
+		min = 1
+	}
+
+	patterns := schemaPatterns(schema)
+	if len(patterns) == 0 && g.example {
+		n := len("example")
+		if n < min {
+			n = min
+		}
+		if n > max {
+			n = max
+		}
+		return strings.Repeat("example", n/len("example")+1)[:n]
+	}
+	if len(patterns) == 0 {
+		return g.letters(g.count(min, max))
+	}
+
+	var s string
+	for attempt := 0; attempt < generatorMaxAttempts; attempt++ {
+		s = g.pattern(patterns[0])
+		n := len([]rune(s))
+		ok := n >= min && n <= max
+		for _, re := range patterns[1:] {
+			ok = ok && re.MatchString(s)
+		}
+		if ok {
+			break
+		}
+	}
+	return s
+}
+
+// schemaPatterns returns the compiled patterns of schema.
+func schemaPatterns(schema map[string]any) []*regexp.Regexp {
+	var patterns []string
+	if p, ok := schema["pattern"].(string); ok {
+		patterns = append(patterns, p)
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, s := range allOf {
+			if p, ok := s.(map[string]any)["pattern"].(string); ok {
+				patterns = append(patterns, p)
+			}
+		}
+	}
+
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// time generates a time between 2000 and 2030 in UTC.
+func (g *Generator) time() time.Time {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(g.rand.Int63n(int64(30 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// letters generates n lowercase letters.
+func (g *Generator) letters(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + g.rand.Intn(26))
+	}
+	return string(b)
+}
+
+// pattern generates a string that re matches. Unanchored patterns are matched in full so the string is only what the
+// pattern requires.
+func (g *Generator) pattern(re *regexp.Regexp) string {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	sb := &strings.Builder{}
+	g.writePattern(sb, parsed.Simplify())
+	return sb.String()
+}
+
+// writePattern writes a string matched by re to sb. Repetitions are limited to a few more than their minimum.
+func (g *Generator) writePattern(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		sb.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		sb.WriteByte(byte('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.writePattern(sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writePattern(sb, sub)
+		}
+	case syntax.OpAlternate:
+		g.writePattern(sb, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := 0, 3
+		switch re.Op {
+		case syntax.OpPlus:
+			min = 1
+		case syntax.OpQuest:
+			max = 1
+		case syntax.OpRepeat:
+			min, max = re.Min, re.Max
+			if max == -1 || max > min+3 {
+				max = min + 3
+			}
+		}
+		for i := min + g.rand.Intn(max-min+1); i > 0; i-- {
+			g.writePattern(sb, re.Sub[0])
+		}
+	}
+}
+
+// classRune returns a rune in the ranges of a character class. Printable ASCII is preferred.
+func (g *Generator) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r <= '~'; r++ {
+			if r >= ' ' {
+				printable = append(printable, r)
+			}
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.rand.Intn(len(printable))]
+	}
+	if len(ranges) == 0 {
+		return 'a'
+	}
+	return ranges[0]
+}
+
+// violation changes a record so that field is invalid.
+type violation struct {
+	field string
+	apply func(record map[string]any)
+}
+
+// violations returns the ways a record can be made invalid according to the schema of the generator.
+func (g *Generator) violations() []violation {
+	properties, _ := g.schema["properties"].(map[string]any)
+	requiredFields, _ := g.schema["required"].([]string)
+
+	var violations []violation
+	set := func(field string, value any) {
+		violations = append(violations, violation{field: field, apply: func(record map[string]any) {
+			record[field] = value
+		}})
+	}
+
+	for _, field := range requiredFields {
+		field := field
+		violations = append(violations, violation{field: field, apply: func(record map[string]any) {
+			delete(record, field)
+		}})
+	}
+
+	for _, field := range sortedKeys(properties) {
+		schema := properties[field].(map[string]any)
+
+		switch schemaType(schema) {
+		case "integer", "number", "boolean", "array", "object":
+			set(field, "not a "+schemaType(schema))
+		}
+		if _, ok := schema["format"]; ok {
+			set(field, "not a "+schema["format"].(string))
+		}
+		if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+			set(field, fmt.Sprintf("not %v", enum[0]))
+		}
+		if min, ok := schema["minLength"].(int); ok && min > 1 {
+			set(field, strings.Repeat("a", min-1))
+		}
+		if max, ok := schema["maxLength"].(int); ok {
+			set(field, strings.Repeat("a", max+1))
+		}
+		for _, keyword := range []string{"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum"} {
+			n, ok := schema[keyword].(json.Number)
+			if !ok {
+				continue
+			}
+			d, err := decimal.NewFromString(string(n))
+			if err != nil {
+				continue
+			}
+			switch keyword {
+			case "minimum":
+				d = d.Sub(decimal.NewFromInt(1))
+			case "maximum":
+				d = d.Add(decimal.NewFromInt(1))
+			}
+			set(field, json.Number(d.String()))
+		}
+	}
+
+	return violations
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// cloneValue returns a deep copy of the maps and slices of a generated value.
+func cloneValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for k, v := range value {
+			m[k] = cloneValue(v)
+		}
+		return m
+	case []any:
+		s := make([]any, len(value))
+		for i, v := range value {
+			s[i] = cloneValue(v)
+		}
+		return s
+	}
+	return value
+}
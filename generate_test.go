@@ -0,0 +1,135 @@
+package ensure_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require(), ensure.MaxLen(20))
+		r.Ensure("age", ensure.Int32(), ensure.Between(18, 99), ensure.NotNil())
+		r.Ensure("color", ensure.Lower(), ensure.OneOf("red", "green"))
+		r.Ensure("code", ensure.Match(regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)), ensure.Require())
+		r.Ensure("id", ensure.UUID(), ensure.NotNil())
+		r.Ensure("born", ensure.Date())
+		r.Ensure("website", ensure.URL())
+		r.Ensure("active", ensure.Bool())
+		element := ensure.All(ensure.SingleLineString(), ensure.Require())
+		r.Ensure("tags", ensure.Slice[string](element), ensure.MaxLen(3))
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("zip", ensure.Match(regexp.MustCompile(`^\d{5}$`)), ensure.Require())
+		}))
+		r.Ensure("price", ensure.Decimal(), ensure.GreaterThan("0.5"), ensure.LessThan(1000))
+	})
+
+	for seed := int64(0); seed < 50; seed++ {
+		g := ensure.NewGenerator(re, seed)
+
+		record, err := g.Valid()
+		require.NoErrorf(t, err, "seed %d", seed)
+		assert.Containsf(t, record, "name", "seed %d", seed)
+		_, err = re.Ensure(record)
+		require.NoErrorf(t, err, "seed %d: %v", seed, record)
+
+		record, field, err := g.Invalid()
+		require.NoErrorf(t, err, "seed %d", seed)
+		_, err = re.Ensure(record)
+		var recordErrs *ensure.RecordErrors
+		require.ErrorAsf(t, err, &recordErrs, "seed %d: %v", seed, record)
+		var fields []any
+		for _, fe := range recordErrs.FieldErrors() {
+			fields = append(fields, fe.Path[0])
+		}
+		assert.Containsf(t, fields, field, "seed %d", seed)
+	}
+
+	record1, err := ensure.NewGenerator(re, 42).Valid()
+	require.NoError(t, err)
+	record2, err := ensure.NewGenerator(re, 42).Valid()
+	require.NoError(t, err)
+	assert.Equal(t, record1, record2)
+}
+
+func TestGeneratorFailure(t *testing.T) {
+	errAlways := errors.New("always fails")
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("custom", ensure.Require(), ensure.EnsurerFunc(func(value any) (any, error) {
+			return nil, errAlways
+		}))
+	})
+
+	_, err := ensure.NewGenerator(re, 1).Valid()
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.ErrorIs(t, recordErrs.ByField()["custom"][0].Err, errAlways)
+
+	_, _, err = ensure.NewGenerator(re, 1).Invalid()
+	assert.ErrorAs(t, err, &recordErrs)
+
+	re = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("anything")
+	})
+	_, _, err = ensure.NewGenerator(re, 1).Invalid()
+	assert.Error(t, err)
+}
+
+func TestRecordEnsurerExample(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require(), ensure.MaxLen(20))
+		r.Ensure("initials", ensure.SingleLineString(), ensure.MaxLen(3))
+		r.Ensure("age", ensure.Int32(), ensure.Between(18, 99))
+		r.Ensure("quantity", ensure.Int32())
+		r.Ensure("price", ensure.Decimal(), ensure.GreaterThan("0.5"))
+		r.Ensure("color", ensure.Lower(), ensure.OneOf("red", "green"))
+		r.Ensure("active", ensure.Bool())
+		r.Ensure("code", ensure.Match(regexp.MustCompile(`^[A-Z]{3}$`)))
+		r.Ensure("tags", ensure.Slice[string](ensure.SingleLineString()))
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("city", ensure.SingleLineString(), ensure.Require())
+		}))
+		r.Ensure("id", ensure.UUID())
+		r.Ensure("born", ensure.Date())
+	})
+
+	example := re.Example()
+	assert.Equal(t, example, re.Example())
+
+	code := example["code"].(string)
+	assert.Regexp(t, `^[A-Z]{3}$`, code)
+	id := example["id"].(string)
+	born := example["born"].(string)
+	assert.Equal(t, map[string]any{
+		"name":     "example",
+		"initials": "exa",
+		"age":      int64(18),
+		"quantity": int64(1),
+		"price":    1.5,
+		"color":    "red",
+		"active":   true,
+		"code":     code,
+		"tags":     []any{"example"},
+		"address":  map[string]any{"city": "example"},
+		"id":       id,
+		"born":     born,
+	}, example)
+
+	_, err := re.Ensure(example)
+	assert.NoError(t, err)
+
+	re = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("even", ensure.Int32(), ensure.EnsurerFunc(func(value any) (any, error) {
+			if value != nil && value.(int32)%2 != 0 {
+				return nil, errors.New("must be even")
+			}
+			return value, nil
+		}))
+	})
+	_, err = re.Ensure(re.Example())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,187 @@
+package ensure
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DOT returns a Graphviz DOT graph of re for documentation and reviews of complex definitions. Each field is a node
+// labeled with its rules in order. Fields of nested records, including those of the elements of Slice and the values of
+// Map, are joined to their parent by dashed edges. Cross-field rules such as EnsureFieldLessThan are edges from the
+// field they depend on. The rules of EnsureIf and RequireIf are shown with if=true since their condition is not called.
+// Like Rules, the graph is found by running each phase of re against an empty record.
+func (re *RecordEnsurer) DOT() string {
+	g := re.graph()
+	sb := &strings.Builder{}
+
+	sb.WriteString("digraph {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, n := range g.nodes {
+		lines := make([]string, len(n.lines))
+		for i, line := range n.lines {
+			lines[i] = dotEscape(line)
+		}
+		fmt.Fprintf(sb, "\t\"%s\" [label=\"%s\"];\n", dotEscape(n.field), strings.Join(lines, `\n`))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(sb, "\t\"%s\" -> \"%s\"", dotEscape(e.from), dotEscape(e.to))
+		if e.label == "" {
+			sb.WriteString(" [style=dashed];\n")
+		} else {
+			fmt.Fprintf(sb, " [label=\"%s\"];\n", dotEscape(e.label))
+		}
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// Mermaid returns a Mermaid flowchart of re. It is the same graph as DOT.
+func (re *RecordEnsurer) Mermaid() string {
+	g := re.graph()
+	sb := &strings.Builder{}
+
+	sb.WriteString("flowchart LR\n")
+	for i, n := range g.nodes {
+		lines := make([]string, len(n.lines))
+		for j, line := range n.lines {
+			lines[j] = mermaidEscape(line)
+		}
+		fmt.Fprintf(sb, "    n%d[\"%s\"]\n", i, strings.Join(lines, "<br/>"))
+	}
+	for _, e := range g.edges {
+		if e.label == "" {
+			fmt.Fprintf(sb, "    n%d -.-> n%d\n", g.index[e.from], g.index[e.to])
+		} else {
+			fmt.Fprintf(sb, "    n%d -->|%s| n%d\n", g.index[e.from], mermaidEscape(e.label), g.index[e.to])
+		}
+	}
+
+	return sb.String()
+}
+
+// graphNode is a field in the graph of a RecordEnsurer. lines are the field and its rules.
+type graphNode struct {
+	field string
+	lines []string
+}
+
+// graphEdge joins two fields. label is the kind of a dependency or empty for a nested field.
+type graphEdge struct {
+	from  string
+	to    string
+	label string
+}
+
+// recordGraph is the graph of the fields of a RecordEnsurer.
+type recordGraph struct {
+	nodes []*graphNode
+	edges []graphEdge
+	index map[string]int
+}
+
+// graph returns the graph of re.
+func (re *RecordEnsurer) graph() *recordGraph {
+	g := &recordGraph{index: make(map[string]int)}
+	g.record("", re)
+	return g
+}
+
+// node returns the node of field, adding it if needed.
+func (g *recordGraph) node(field string) *graphNode {
+	if i, ok := g.index[field]; ok {
+		return g.nodes[i]
+	}
+	n := &graphNode{field: field, lines: []string{field}}
+	g.index[field] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+	return n
+}
+
+// record adds the fields of re with prefix prepended to their names and returns them.
+func (g *recordGraph) record(prefix string, re *RecordEnsurer) []string {
+	rd := re.describe()
+
+	var fields []string
+	for _, df := range rd.fields {
+		field := prefix + df.name
+		fields = append(fields, field)
+
+		n := g.node(field)
+		if df.label != "" {
+			n.lines[0] += " (" + df.label + ")"
+		}
+		for _, e := range df.ensurers {
+			r := Describe(e)
+			n.lines = append(n.lines, ruleString(r))
+			g.children(field, field, r)
+		}
+	}
+
+	for _, d := range rd.dependencies {
+		g.node(prefix + d.from)
+		g.node(prefix + d.to)
+		g.edges = append(g.edges, graphEdge{from: prefix + d.from, to: prefix + d.to, label: d.kind})
+	}
+
+	return fields
+}
+
+// children adds the fields of the records nested in r as children of parent. path is the location of the value of r
+// such as "items[]" for the elements of the field "items".
+func (g *recordGraph) children(parent, path string, r Rule) {
+	switch r.Kind {
+	case "nested":
+		for _, child := range g.record(path+".", r.Params["record"].(*RecordEnsurer)) {
+			g.edges = append(g.edges, graphEdge{from: parent, to: child})
+		}
+	case "slice":
+		g.children(parent, path+"[]", Describe(r.Params["element"].(Ensurer)))
+	case "map":
+		g.children(parent, path+".*", Describe(r.Params["value"].(Ensurer)))
+	default:
+		if ensurers, ok := r.Params["ensurers"].([]Ensurer); ok {
+			for _, e := range ensurers {
+				g.children(parent, path, Describe(e))
+			}
+		}
+	}
+}
+
+// ruleString formats r like "maxlen(max=100)". Wrapped ensurers are formatted recursively and nested records are
+// omitted.
+func ruleString(r Rule) string {
+	var args []string
+	for _, k := range sortedKeys(r.Params) {
+		switch v := r.Params[k].(type) {
+		case *RecordEnsurer:
+		case Ensurer:
+			args = append(args, ruleString(Describe(v)))
+		case []Ensurer:
+			for _, e := range v {
+				args = append(args, ruleString(Describe(e)))
+			}
+		default:
+			switch reflect.ValueOf(v).Kind() {
+			case reflect.Invalid, reflect.Func, reflect.Chan, reflect.Pointer, reflect.UnsafePointer:
+				continue
+			}
+			args = append(args, fmt.Sprintf("%s=%v", k, v))
+		}
+	}
+
+	if len(args) == 0 {
+		return r.Kind
+	}
+	return r.Kind + "(" + strings.Join(args, ", ") + ")"
+}
+
+// dotEscape escapes s for a quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// mermaidEscape escapes s for a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;", "\n", " ").Replace(s)
+}
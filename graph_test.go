@@ -0,0 +1,54 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func graphRecordEnsurer() *ensure.RecordEnsurer {
+	return ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Label("name", `"Full" name`)
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require(), ensure.MaxLen(100))
+		r.Ensure("country", ensure.OneOf("US", "CA"))
+		r.RequireIf(ensure.FieldEquals("country", "US"), "state")
+		r.Ensure("starts_on", ensure.Date())
+		r.Ensure("ends_on", ensure.Date())
+		r.EnsureFieldLessThan("starts_on", "ends_on")
+		r.Ensure("items", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("price", ensure.Decimal(), ensure.IfNotNil(ensure.GreaterThan(0)))
+		})))
+	})
+}
+
+func TestRecordEnsurerDOT(t *testing.T) {
+	assert.Equal(t, `digraph {
+	rankdir=LR;
+	node [shape=box];
+	"name" [label="name (\"Full\" name)\nsingleline\nrequire\nmaxlen(max=100)"];
+	"country" [label="country\noneof(allowed=[US CA])"];
+	"state" [label="state\nrequire(if=true)"];
+	"starts_on" [label="starts_on\ndate(formats=[2006-01-02])"];
+	"ends_on" [label="ends_on\ndate(formats=[2006-01-02])"];
+	"items" [label="items\nslice(nested)"];
+	"items[].price" [label="items[].price\ndecimal\nifnotnil(gt(greater_than=0))"];
+	"items" -> "items[].price" [style=dashed];
+	"starts_on" -> "ends_on" [label="lessthan"];
+}
+`, graphRecordEnsurer().DOT())
+}
+
+func TestRecordEnsurerMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart LR
+    n0["name (#quot;Full#quot; name)<br/>singleline<br/>require<br/>maxlen(max=100)"]
+    n1["country<br/>oneof(allowed=[US CA])"]
+    n2["state<br/>require(if=true)"]
+    n3["starts_on<br/>date(formats=[2006-01-02])"]
+    n4["ends_on<br/>date(formats=[2006-01-02])"]
+    n5["items<br/>slice(nested)"]
+    n6["items[].price<br/>decimal<br/>ifnotnil(gt(greater_than=0))"]
+    n5 -.-> n6
+    n3 -->|lessthan| n4
+`, graphRecordEnsurer().Mermaid())
+}
@@ -0,0 +1,63 @@
+package ensure
+
+import (
+	"errors"
+)
+
+// GraphQLError is a GraphQL response error as expected by Apollo-style clients. It marshals to JSON in the format
+// defined by the GraphQL specification.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors converts err into GraphQL errors with one entry per field error. Each entry's extensions hold the
+// error code, the field path formatted like "items[2].price", and the error params if any. path is the location of
+// the GraphQL field that was being resolved, e.g. "createUser", and becomes the path of every entry. If err is not a
+// *RecordErrors then a single entry is returned. If err is nil then nil is returned.
+func GraphQLErrors(err error, path ...any) []GraphQLError {
+	if err == nil {
+		return nil
+	}
+
+	var recordErrors *RecordErrors
+	if !errors.As(err, &recordErrors) {
+		return []GraphQLError{newGraphQLError(err, nil, path)}
+	}
+
+	fieldErrors := recordErrors.FieldErrors()
+	gqlErrors := make([]GraphQLError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		gqlErrors[i] = newGraphQLError(fe.Err, fe.Path, path)
+	}
+
+	return gqlErrors
+}
+
+func newGraphQLError(err error, field Path, path []any) GraphQLError {
+	gqlErr := GraphQLError{
+		Message:    err.Error(),
+		Path:       path,
+		Extensions: map[string]any{},
+	}
+
+	var ensureErr *Error
+	if errors.As(err, &ensureErr) {
+		gqlErr.Extensions["code"] = ensureErr.Code
+		if len(ensureErr.Params) > 0 {
+			gqlErr.Extensions["params"] = ensureErr.Params
+		}
+	}
+
+	if len(field) > 0 {
+		gqlErr.Message = field.String() + ": " + gqlErr.Message
+		gqlErr.Extensions["field"] = field.String()
+	}
+
+	if len(gqlErr.Extensions) == 0 {
+		gqlErr.Extensions = nil
+	}
+
+	return gqlErr
+}
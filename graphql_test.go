@@ -0,0 +1,50 @@
+package ensure_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLErrors(t *testing.T) {
+	record := ensure.GetterSetterMap{
+		"name":  "",
+		"items": []any{map[string]any{"qty": "abc"}},
+	}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("items", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("qty", ensure.Int32())
+		})))
+	})
+	require.Error(t, errs)
+
+	gqlErrs := ensure.GraphQLErrors(errs, "createOrder")
+	require.Len(t, gqlErrs, 2)
+	assert.Equal(t, ensure.GraphQLError{
+		Message:    "name: cannot be nil or empty",
+		Path:       []any{"createOrder"},
+		Extensions: map[string]any{"code": "required", "field": "name"},
+	}, gqlErrs[0])
+	assert.Equal(t, "items[0].qty", gqlErrs[1].Extensions["field"])
+	assert.Equal(t, "not_a_number", gqlErrs[1].Extensions["code"])
+
+	buf, err := json.Marshal(gqlErrs[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message":"name: cannot be nil or empty","path":["createOrder"],"extensions":{"code":"required","field":"name"}}`, string(buf))
+
+	gqlErrs = ensure.GraphQLErrors(ensure.NewError("too_large", "too large", map[string]any{"max": 10}))
+	assert.Equal(t, []ensure.GraphQLError{{
+		Message:    "too large",
+		Extensions: map[string]any{"code": "too_large", "params": map[string]any{"max": 10}},
+	}}, gqlErrs)
+
+	gqlErrs = ensure.GraphQLErrors(errors.New("boom"))
+	assert.Equal(t, []ensure.GraphQLError{{Message: "boom"}}, gqlErrs)
+
+	assert.Nil(t, ensure.GraphQLErrors(nil))
+}
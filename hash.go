@@ -0,0 +1,208 @@
+package ensure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// HashMatches returns a EnsureRecordFunc that verifies the digest of dataField computed with algo matches the
+// client-provided digest in hashField. algo must be "md5", "sha1", "sha256", or "sha512" or HashMatches panics.
+// dataField must be a base64 encoded string, []byte, *multipart.FileHeader, or a value such as *os.File that implements
+// io.ReaderAt and io.Seeker. hashField must be a hex or base64 encoded string. If either field is nil then nothing is
+// checked. A digest that does not match is added as an error to hashField.
+//
+// It is intended to be called from within an EnsureRecordFunc after dataField and hashField have been ensured:
+//
+//	ensure.HashMatches("file", "checksum", "sha256")(r)
+func HashMatches(dataField, hashField, algo string) EnsureRecordFunc {
+	newHash, ok := hashAlgorithms[strings.ToLower(algo)]
+	if !ok {
+		panic(fmt.Errorf("unsupported hash algorithm: %s", algo))
+	}
+
+	return func(r *RecordWithErrors) {
+		data := r.Get(dataField)
+		expectedValue := r.Get(hashField)
+		if data == nil || expectedValue == nil {
+			return
+		}
+
+		h := newHash()
+
+		expected, ok := decodeDigest(expectedValue, h.Size())
+		if !ok {
+			r.Add(hashField, NewError("invalid_hash", "not a valid hash", nil))
+			return
+		}
+
+		if s, ok := data.(string); ok {
+			b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+			if err != nil {
+				r.Add(dataField, NewError("invalid_base64", "not valid base64", nil))
+				return
+			}
+			data = b
+		}
+
+		reader, closeUpload, err := openUpload(data)
+		if err != nil {
+			r.Add(dataField, err)
+			return
+		}
+		defer closeUpload()
+
+		_, err = io.Copy(h, reader)
+		if err != nil {
+			r.Add(dataField, NewError("not_a_file", "not a file", nil))
+			return
+		}
+
+		if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+			r.Add(hashField, NewError("hash_mismatch", "does not match", map[string]any{"algorithm": algo}))
+		}
+	}
+}
+
+// decodeDigest decodes a hex or base64 encoded digest of size bytes.
+func decodeDigest(value any, size int) ([]byte, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+	s = strings.TrimSpace(s)
+
+	if len(s) == hex.EncodedLen(size) {
+		if b, err := hex.DecodeString(s); err == nil {
+			return b, true
+		}
+	}
+
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if b, err := encoding.DecodeString(s); err == nil && len(b) == size {
+			return b, true
+		}
+	}
+
+	return nil, false
+}
+
+// HashSHA256Hex returns a Ensurer that replaces value with its SHA-256 digest as a lower case hex string. value must be
+// a string or []byte. If value is nil then nil is returned.
+func HashSHA256Hex() Ensurer {
+	return describe("hashsha256hex", nil, hashTransform(func(b []byte) (string, error) {
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:]), nil
+	}))
+}
+
+// HMAC returns a Ensurer that replaces value with its HMAC-SHA256 computed with key as a lower case hex string. It is
+// intended for values such as webhook secrets and API tokens that must be looked up later but never stored in the
+// clear. key must not be empty or HMAC panics. value must be a string or []byte. If value is nil then nil is returned.
+func HMAC(key []byte) Ensurer {
+	if len(key) == 0 {
+		panic(errors.New("key must not be empty"))
+	}
+
+	return describe("hmac", nil, hashTransform(func(b []byte) (string, error) {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(b)
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}))
+}
+
+// BcryptHash returns a Ensurer that replaces value with its bcrypt hash at cost. It is intended for passwords so they
+// are irreversibly hashed in the same pass that checks their length. cost must be between bcrypt.MinCost and
+// bcrypt.MaxCost or BcryptHash panics. value must be a string or []byte of at most 72 bytes. If value is nil then nil
+// is returned.
+//
+// High costs can take seconds, so BcryptHash honors the context of the record when used with RecordContext or
+// RecordEnsurer.EnsureContext. If ctx is done before or while hashing then ctx.Err() is returned immediately. bcrypt
+// itself cannot be interrupted, so a hash that was started finishes in the background and is discarded.
+func BcryptHash(cost int) Ensurer {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		panic(fmt.Errorf("cost must be between %d and %d: %d", bcrypt.MinCost, bcrypt.MaxCost, cost))
+	}
+
+	params := map[string]any{"cost": cost}
+	return describe("bcrypthash", params, hashTransformContext(func(ctx context.Context, b []byte) (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		type result struct {
+			hash []byte
+			err  error
+		}
+		done := make(chan result, 1)
+		password := append([]byte(nil), b...)
+		go func() {
+			hash, err := bcrypt.GenerateFromPassword(password, cost)
+			done <- result{hash: hash, err: err}
+		}()
+
+		var r result
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case r = <-done:
+		}
+
+		if r.err != nil {
+			if errors.Is(r.err, bcrypt.ErrPasswordTooLong) {
+				return "", NewError("too_long", "too long", map[string]any{"max": 72})
+			}
+			return "", r.err
+		}
+		return string(r.hash), nil
+	}))
+}
+
+func hashTransform(fn func([]byte) (string, error)) Ensurer {
+	return hashTransformContext(func(_ context.Context, b []byte) (string, error) {
+		return fn(b)
+	})
+}
+
+func hashTransformContext(fn func(context.Context, []byte) (string, error)) Ensurer {
+	return EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		var b []byte
+		switch value := value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			b = []byte(value)
+		case []byte:
+			b = value
+		default:
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		s, err := fn(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	})
+}
@@ -0,0 +1,104 @@
+package ensure_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashMatches(t *testing.T) {
+	// sha256("hello")
+	const helloHex = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	const helloBase64 = "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="
+
+	tests := []struct {
+		record     ensure.GetterSetterMap
+		errorField string
+	}{
+		{ensure.GetterSetterMap{"data": []byte("hello"), "sha": helloHex}, ""},
+		{ensure.GetterSetterMap{"data": "aGVsbG8=", "sha": helloBase64}, ""},
+		{ensure.GetterSetterMap{"data": []byte("hello"), "sha": nil}, ""},
+		{ensure.GetterSetterMap{"data": []byte("hellO"), "sha": helloHex}, "sha"},
+		{ensure.GetterSetterMap{"data": []byte("hello"), "sha": "abc"}, "sha"},
+		{ensure.GetterSetterMap{"data": "not base64!", "sha": helloHex}, "data"},
+	}
+
+	for i, tt := range tests {
+		err := ensure.Record(tt.record, ensure.HashMatches("data", "sha", "sha256"))
+		if tt.errorField == "" {
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			var etErr *errortree.Node
+			require.ErrorAsf(t, err, &etErr, "%d", i)
+			assert.Lenf(t, etErr.Get([]any{tt.errorField}), 1, "%d", i)
+		}
+	}
+
+	assert.Panics(t, func() { ensure.HashMatches("data", "sha", "crc32") })
+}
+
+func TestHashTransforms(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.HashSHA256Hex(), "hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", true},
+		{ensure.HashSHA256Hex(), []byte("hello"), "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", true},
+		{ensure.HMAC([]byte("key")), "The quick brown fox jumps over the lazy dog", "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", true},
+		{ensure.HashSHA256Hex(), 42, nil, false},
+		{ensure.HMAC([]byte("key")), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	assert.Panics(t, func() { ensure.HMAC(nil) })
+}
+
+func TestBcryptHash(t *testing.T) {
+	value, err := ensure.BcryptHash(bcrypt.MinCost).Ensure("correct horse battery staple")
+	require.NoError(t, err)
+	hash, ok := value.(string)
+	require.True(t, ok)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("correct horse battery staple")))
+
+	_, err = ensure.BcryptHash(bcrypt.MinCost).Ensure(strings.Repeat("x", 73))
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "too_long", ensureErr.Code)
+
+	value, err = ensure.BcryptHash(bcrypt.MinCost).Ensure(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	record := ensure.GetterSetterMap{"password": "correct horse battery staple"}
+	err = ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("password", ensure.BcryptHash(bcrypt.MinCost))
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.ErrorIs(t, recordErrs.ByField()["password"][0].Err, context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = ensure.BcryptHash(14).(ensure.EnsurerContext).EnsureContext(ctx, "correct horse battery staple")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Panics(t, func() { ensure.BcryptHash(bcrypt.MaxCost + 1) })
+}
@@ -0,0 +1,40 @@
+package ensure
+
+import (
+	"html"
+	"regexp"
+)
+
+var (
+	htmlScriptRegexp  = regexp.MustCompile(`(?is)<script\b.*?(?:</script\s*>|$)`)
+	htmlStyleRegexp   = regexp.MustCompile(`(?is)<style\b.*?(?:</style\s*>|$)`)
+	htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?(?:-->|$)`)
+	htmlTagRegexp     = regexp.MustCompile(`(?s)</?[A-Za-z][^>]*(?:>|$)|<![^>]*>|<\?[^>]*>`)
+)
+
+// StripHTML returns a Ensurer that removes HTML from value. Character references such as &amp; are decoded first so
+// encoded tags such as &lt;script&gt; are removed too. Then tags and comments are removed and script and style elements
+// are removed along with their contents. Removing is repeated until nothing changes so tags cannot be assembled from
+// the text around removed tags. The result is plain text which must still be escaped when it is rendered as HTML. If
+// value is nil then nil is returned. If value is not a string then an error is returned.
+func StripHTML() Ensurer {
+	return describe("striphtml", nil, transformString(func(s string) string {
+		s = html.UnescapeString(s)
+		for {
+			stripped := htmlScriptRegexp.ReplaceAllString(s, "")
+			stripped = htmlStyleRegexp.ReplaceAllString(stripped, "")
+			stripped = htmlCommentRegexp.ReplaceAllString(stripped, "")
+			stripped = htmlTagRegexp.ReplaceAllString(stripped, "")
+			if stripped == s {
+				return s
+			}
+			s = stripped
+		}
+	}))
+}
+
+// EscapeHTML returns a Ensurer that escapes the HTML special characters <, >, &, ', and " in value. If value is nil
+// then nil is returned. If value is not a string then an error is returned.
+func EscapeHTML() Ensurer {
+	return describe("escapehtml", nil, transformString(html.EscapeString))
+}
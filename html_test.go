@@ -0,0 +1,56 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"Hello <b>world</b>", "Hello world", true},
+		{"a<script type=\"text/javascript\">alert(1)</script>b", "ab", true},
+		{"a<SCRIPT>alert(1)</SCRIPT >b<style>p{}</style>c", "abc", true},
+		{"a<script>alert(1)", "a", true},
+		{"x<!-- hidden <b>y</b> -->z", "xz", true},
+		{"<p class=\"x\">Tom &amp; Jerry</p><br/>", "Tom & Jerry", true},
+		{"1 < 2 and 3 > 2", "1 < 2 and 3 > 2", true},
+		{"<!DOCTYPE html><?xml?>text", "text", true},
+		{"<a href='x'", "", true},
+		{"&lt;script&gt;alert(1)&lt;/script&gt;ok", "ok", true},
+		{"&lt;b&gt;bold&lt;/b&gt; &amp;lt;i&amp;gt;", "bold &lt;i&gt;", true},
+		{"<<b>script>alert(1)</script>", "", true},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.StripHTML().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{`<b class="x">Tom & 'Jerry'</b>`, "&lt;b class=&#34;x&#34;&gt;Tom &amp; &#39;Jerry&#39;&lt;/b&gt;", true},
+		{"plain", "plain", true},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.EscapeHTML().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
@@ -0,0 +1,76 @@
+package ensure
+
+import (
+	"errors"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// HTMXErrorID returns the element id HTMXErrors uses for the error span of field. field is a path formatted like
+// "items[2].price" which becomes "error-items-2-price".
+func HTMXErrorID(field string) string {
+	sb := &strings.Builder{}
+	sb.WriteString("error-")
+	lastWasDash := true
+	for _, r := range field {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			sb.WriteRune(r)
+			lastWasDash = false
+		} else if !lastWasDash {
+			sb.WriteByte('-')
+			lastWasDash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// HTMXErrors renders err as HTML fragments for htmx-driven forms. Each field with errors becomes a
+// <span id="error-field" class="error" hx-swap-oob="true"> containing its escaped messages joined by "; ". fields are
+// the names of all the fields on the form; each one without an error becomes an empty span so the out-of-band swap
+// clears any error left from a previous submission. Errors that are not field errors are rendered in a span with the id
+// "error-form". If err is nil only the empty spans are rendered.
+func HTMXErrors(err error, fields ...string) template.HTML {
+	var order []string
+	messages := map[string][]string{}
+	addMessage := func(field, message string) {
+		if _, ok := messages[field]; !ok {
+			order = append(order, field)
+		}
+		messages[field] = append(messages[field], message)
+	}
+
+	if err != nil {
+		var recordErrors *RecordErrors
+		if errors.As(err, &recordErrors) {
+			for _, fe := range recordErrors.FieldErrors() {
+				addMessage(fe.Path.String(), fe.Err.Error())
+			}
+		} else {
+			addMessage("form", err.Error())
+		}
+	}
+
+	for _, field := range fields {
+		if _, ok := messages[field]; !ok {
+			order = append(order, field)
+			messages[field] = nil
+		}
+	}
+
+	sb := &strings.Builder{}
+	for _, field := range order {
+		sb.WriteString(`<span id="`)
+		sb.WriteString(html.EscapeString(HTMXErrorID(field)))
+		sb.WriteString(`" class="error" hx-swap-oob="true">`)
+		for i, message := range messages[field] {
+			if i > 0 {
+				sb.WriteString("; ")
+			}
+			sb.WriteString(html.EscapeString(message))
+		}
+		sb.WriteString("</span>\n")
+	}
+
+	return template.HTML(sb.String())
+}
@@ -0,0 +1,41 @@
+package ensure_test
+
+import (
+	"errors"
+	"html/template"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMXErrorID(t *testing.T) {
+	assert.Equal(t, "error-name", ensure.HTMXErrorID("name"))
+	assert.Equal(t, "error-items-2-price", ensure.HTMXErrorID("items[2].price"))
+	assert.Equal(t, "error-a-b", ensure.HTMXErrorID("a<\"b\">"))
+}
+
+func TestHTMXErrors(t *testing.T) {
+	record := ensure.GetterSetterMap{"name": "", "age": "<b>"}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+		r.Add("age", errors.New("must be <18> & up"))
+	})
+	require.Error(t, errs)
+
+	assert.Equal(t, template.HTML(
+		`<span id="error-name" class="error" hx-swap-oob="true">cannot be nil or empty</span>`+"\n"+
+			`<span id="error-age" class="error" hx-swap-oob="true">not a valid number; must be &lt;18&gt; &amp; up</span>`+"\n"+
+			`<span id="error-email" class="error" hx-swap-oob="true"></span>`+"\n",
+	), ensure.HTMXErrors(errs, "name", "email", "age"))
+
+	assert.Equal(t, template.HTML(
+		`<span id="error-form" class="error" hx-swap-oob="true">boom</span>`+"\n",
+	), ensure.HTMXErrors(errors.New("boom")))
+
+	assert.Equal(t, template.HTML(
+		`<span id="error-name" class="error" hx-swap-oob="true"></span>`+"\n",
+	), ensure.HTMXErrors(nil, "name"))
+}
@@ -0,0 +1,183 @@
+package ensure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// Headers adapts an http.Header to a GetterSetter so a set of headers can be ensured like any other record. Header
+// names are canonicalized. Get returns nil if the header is not present, a string if it has one value, and a []any of
+// strings if it has multiple values. Set stores value as the header's only value, a []string or []any as multiple
+// values, and deletes the header if value is nil. Values that are not strings are converted with fmt.Sprint.
+type Headers http.Header
+
+func (h Headers) Get(key string) any {
+	values := http.Header(h).Values(key)
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	}
+
+	anys := make([]any, len(values))
+	for i, v := range values {
+		anys[i] = v
+	}
+	return anys
+}
+
+func (h Headers) Set(key string, value any) {
+	header := http.Header(h)
+
+	switch value := value.(type) {
+	case nil:
+		header.Del(key)
+	case []string:
+		header.Del(key)
+		for _, v := range value {
+			header.Add(key, v)
+		}
+	case []any:
+		header.Del(key)
+		for _, v := range value {
+			header.Add(key, convertString(v))
+		}
+	default:
+		header.Set(key, convertString(value))
+	}
+}
+
+// Keys returns the canonical names of the headers in h so it can be used with RecordWithErrors.AllowOnly and
+// RecordWithErrors.EnsurePrefix.
+func (h Headers) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, http.CanonicalHeaderKey(k))
+	}
+	return keys
+}
+
+// Delete deletes the header key from h so it can be used with RecordWithErrors.Permit.
+func (h Headers) Delete(key string) {
+	http.Header(h).Del(key)
+}
+
+// ContentLength returns a Ensurer that converts a Content-Length header value to an int64 between 0 and max. Unlike
+// Int64, only decimal digits are accepted. Multiple values are allowed only if they are identical, as some proxies
+// duplicate the header. If value is nil, a blank string, or no values nil is returned.
+func ContentLength(max int64) Ensurer {
+	return describe("contentlength", map[string]any{"max": max}, EnsurerFunc(func(value any) (any, error) {
+		if values, ok := value.([]any); ok {
+			if len(values) == 0 {
+				return nil, nil
+			}
+			for _, v := range values[1:] {
+				if v != values[0] {
+					return nil, NewError("invalid_content_length", "has conflicting values", nil)
+				}
+			}
+			value = values[0]
+		}
+
+		value = normalizeForParsing(value)
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return nil, NewError("not_a_number", "not a valid number", nil)
+			}
+		}
+
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n > max {
+			return nil, NewError("too_large", "too large", map[string]any{"max": max})
+		}
+
+		return n, nil
+	}))
+}
+
+// BindRequest builds a record from the query parameters and body of r and ensures it with re using the context of r.
+// The body may be a JSON object or a URL encoded or multipart form. It is decoded according to the Content-Type header
+// and is ignored if there is no Content-Type. Query parameters and form values follow the rules of Query. A JSON body
+// is decoded with FromJSON so numbers are json.Number and large integer IDs are not corrupted. Fields in the body take
+// precedence over query parameters of the same name. Files in multipart forms are not included. The size of the body
+// is not limited so r.Body should be wrapped with http.MaxBytesReader for untrusted clients.
+//
+// If the body cannot be decoded an error is returned that is not a *RecordErrors. If any field fails the returned error
+// is a *RecordErrors. In either case the map is nil. WriteErrors writes an appropriate response for either error.
+func BindRequest(r *http.Request, re *RecordEnsurer) (map[string]any, error) {
+	record := make(GetterSetterMap)
+	setValues(record, r.URL.Query())
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Content-Type: %w", err)
+		}
+
+		switch mediaType {
+		case "application/json":
+			body, err := FromJSON(r.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode JSON body: %w", err)
+			}
+			for k, v := range body {
+				record[k] = v
+			}
+		case "application/x-www-form-urlencoded", "multipart/form-data":
+			if mediaType == "multipart/form-data" {
+				err = r.ParseMultipartForm(32 << 20)
+			} else {
+				err = r.ParseForm()
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse form: %w", err)
+			}
+			setValues(record, r.PostForm)
+		default:
+			return nil, fmt.Errorf("unsupported Content-Type: %s", mediaType)
+		}
+	}
+
+	_, err := re.EnsureContext(r.Context(), record)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// WriteErrors writes err as a JSON response to w. If err is a *RecordErrors the status is 422 Unprocessable Entity and
+// the body is an object with an "errors" array holding the "field", "label", "code", and "message" of each error.
+// Otherwise the status is 400 Bad Request and the body is an object with an "error" message. It is intended for errors
+// returned by BindRequest.
+func WriteErrors(w http.ResponseWriter, err error) {
+	var body any
+	status := http.StatusBadRequest
+
+	var recordErrs *RecordErrors
+	if errors.As(err, &recordErrs) {
+		status = http.StatusUnprocessableEntity
+		body = map[string]any{"errors": captureErrors(recordErrs.FieldErrors())}
+	} else {
+		body = map[string]any{"error": err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,189 @@
+package ensure_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "Application/JSON")
+	header.Set("Content-Length", "2048")
+	header.Add("X-Tag", "a")
+	header.Add("X-Tag", "b")
+
+	headers := ensure.Headers(header)
+	assert.Equal(t, "2048", headers.Get("content-length"))
+	assert.Equal(t, []any{"a", "b"}, headers.Get("X-Tag"))
+	assert.Nil(t, headers.Get("X-Missing"))
+
+	errs := ensure.Record(headers, func(r *ensure.RecordWithErrors) {
+		r.Ensure("Content-Type", ensure.MIMEType("application/json"), ensure.Require())
+		r.Ensure("Content-Length", ensure.Int64(), ensure.GreaterThanOrEqual(0), ensure.LessThanOrEqual(1024))
+		r.Ensure("X-Tag", ensure.Slice[string](ensure.SingleLineString()))
+		r.Ensure("X-Request-Id", ensure.UUID(), ensure.Require())
+	})
+	require.Error(t, errs)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, errs, &etErr)
+	assert.Len(t, etErr.Get([]any{"Content-Type"}), 0)
+	assert.Len(t, etErr.Get([]any{"Content-Length"}), 1)
+	assert.Len(t, etErr.Get([]any{"X-Request-Id"}), 1)
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, []string{"a", "b"}, header.Values("X-Tag"))
+
+	headers.Set("X-Tag", nil)
+	assert.Empty(t, header.Values("X-Tag"))
+
+	header = http.Header{}
+	header.Set("Content-Length", "512")
+	header.Set("X-Tenant", "acme")
+	header.Set("X-Debug", strings.Repeat("x", 65))
+	header.Set("Accept", "*/*")
+	errs = ensure.Record(ensure.Headers(header), func(r *ensure.RecordWithErrors) {
+		r.Ensure("Content-Length", ensure.ContentLength(1024))
+		r.EnsurePrefix("X-", ensure.SingleLineString(), ensure.MaxLen(64))
+		r.Permit("Content-Length", "X-Tenant", "X-Debug")
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.Len(t, recordErrs.ByField()["X-Debug"], 1)
+	assert.Equal(t, "512", header.Get("Content-Length"))
+	assert.Equal(t, "acme", header.Get("X-Tenant"))
+	assert.Empty(t, header.Values("Accept"))
+}
+
+func TestContentLength(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"1024", int64(1024), true},
+		{" 0 ", int64(0), true},
+		{[]any{"10", "10"}, int64(10), true},
+		{[]any{"10", "11"}, nil, false},
+		{[]any{}, nil, true},
+		{"1025", nil, false},
+		{"99999999999999999999", nil, false},
+		{"-1", nil, false},
+		{"+1", nil, false},
+		{"1e3", nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+		{42, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.ContentLength(1024).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestBindRequest(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Label("name", "Name")
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+		r.Ensure("page", ensure.Int32())
+	})
+
+	tests := []struct {
+		contentType string
+		body        string
+		expected    map[string]any
+	}{
+		{
+			contentType: "",
+			body:        "",
+			expected:    map[string]any{"name": "query", "age": nil, "page": int32(2)},
+		},
+		{
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name": " Jack ", "age": 42}`,
+			expected:    map[string]any{"name": "Jack", "age": int32(42), "page": int32(2)},
+		},
+		{
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=Jack&age=42",
+			expected:    map[string]any{"name": "Jack", "age": int32(42), "page": int32(2)},
+		},
+	}
+
+	for i, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/people?page=2&name=query", strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		record, err := ensure.BindRequest(req, re)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, record, "%d", i)
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("name", "Jill"))
+	require.NoError(t, mw.Close())
+	req := httptest.NewRequest(http.MethodPost, "/people", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	record, err := ensure.BindRequest(req, re)
+	require.NoError(t, err)
+	assert.Equal(t, "Jill", record["name"])
+
+	idEnsurer := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("id", ensure.Int64(), ensure.Require())
+	})
+	req = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"id": 9007199254740993}`))
+	req.Header.Set("Content-Type", "application/json")
+	record, err = ensure.BindRequest(req, idEnsurer)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), record["id"])
+
+	req = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"age": "abc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	record, err = ensure.BindRequest(req, re)
+	require.Error(t, err)
+	assert.Nil(t, record)
+
+	rec := httptest.NewRecorder()
+	ensure.WriteErrors(rec, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"errors": [
+		{"field": "name", "label": "Name", "code": "required", "message": "cannot be nil or empty"},
+		{"field": "age", "code": "not_a_number", "message": "not a valid number"}
+	]}`, rec.Body.String())
+
+	for i, tt := range []struct{ contentType, body string }{
+		{"application/json", `[1, 2]`},
+		{"application/json", `{"name":`},
+		{"text/plain", "name=Jack"},
+		{"not a media type;;", ""},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+		record, err := ensure.BindRequest(req, re)
+		require.Errorf(t, err, "%d", i)
+		assert.Nilf(t, record, "%d", i)
+
+		var recordErrs *ensure.RecordErrors
+		assert.Falsef(t, errors.As(err, &recordErrs), "%d", i)
+
+		rec := httptest.NewRecorder()
+		ensure.WriteErrors(rec, err)
+		assert.Equalf(t, http.StatusBadRequest, rec.Code, "%d", i)
+	}
+}
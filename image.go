@@ -0,0 +1,50 @@
+package ensure
+
+import (
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+)
+
+// Image returns a Ensurer that validates value is an image no larger than maxWidth by maxHeight pixels. A limit of 0
+// means no limit. If formats are given (e.g. "png", "jpeg", "gif") then only those formats are allowed. Only the image
+// header is decoded. value must be a []byte, *multipart.FileHeader, or a value such as *os.File that implements
+// io.ReaderAt and io.Seeker. value is returned unmodified. If value is nil then nil is returned.
+func Image(maxWidth, maxHeight int, formats ...string) Ensurer {
+	allowedFormats := make(map[string]struct{}, len(formats))
+	for _, format := range formats {
+		allowedFormats[format] = struct{}{}
+	}
+
+	ensurer := EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		r, closeUpload, err := openUpload(value)
+		if err != nil {
+			return nil, err
+		}
+		defer closeUpload()
+
+		config, format, err := image.DecodeConfig(r)
+		if err != nil {
+			return nil, NewError("invalid_image", "not a valid image", nil)
+		}
+
+		if len(allowedFormats) > 0 {
+			if _, ok := allowedFormats[format]; !ok {
+				return nil, NewError("image_format_not_allowed", "image format not allowed", map[string]any{"formats": formats})
+			}
+		}
+
+		if (maxWidth > 0 && config.Width > maxWidth) || (maxHeight > 0 && config.Height > maxHeight) {
+			return nil, NewError("image_too_large", "image too large", map[string]any{"max_width": maxWidth, "max_height": maxHeight})
+		}
+
+		return value, nil
+	})
+
+	return describe("image", map[string]any{"max_width": maxWidth, "max_height": maxHeight, "formats": formats}, ensurer)
+}
@@ -0,0 +1,47 @@
+package ensure_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImage(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 20, 10)))
+	require.NoError(t, err)
+	pngBytes := buf.Bytes()
+
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		success bool
+	}{
+		{ensure.Image(20, 10), pngBytes, true},
+		{ensure.Image(0, 0, "png"), pngBytes, true},
+		{ensure.Image(19, 10), pngBytes, false},
+		{ensure.Image(20, 9), pngBytes, false},
+		{ensure.Image(20, 10, "jpeg", "gif"), pngBytes, false},
+		{ensure.Image(20, 10), []byte("not an image"), false},
+		{ensure.Image(20, 10), "foo", false},
+		{ensure.Image(20, 10), nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+		}
+	}
+
+	reader := bytes.NewReader(pngBytes)
+	_, err = ensure.Image(20, 10).Ensure(reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(pngBytes), reader.Len(), "read position must not change")
+}
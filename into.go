@@ -0,0 +1,127 @@
+package ensure
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EnsureInto ensures value like Ensure and then copies the fields of the ensured record into dest, which must be a
+// pointer to a struct. Each exported struct field is set from the record field named by its "json" tag or else its Go
+// name. Fields tagged "-" are skipped. A nil value sets the struct field to its zero value. A pointer struct field is
+// set to a pointer to the value. Values are converted to the type of the struct field if Go allows the conversion and
+// no information is lost, such as int32 to int64 or string to a named string type. If value fails, the *RecordErrors is
+// returned and dest is not modified. If a value cannot be assigned to its struct field an error is returned.
+func (re *RecordEnsurer) EnsureInto(value any, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a non-nil pointer to a struct: %T", dest)
+	}
+
+	result, err := re.Ensure(value)
+	if err != nil {
+		return err
+	}
+
+	var record GetterSetter
+	switch result := result.(type) {
+	case GetterSetter:
+		record = result
+	case map[string]any:
+		record = GetterSetterMap(result)
+	}
+
+	return copyInto(record, rv.Elem())
+}
+
+// copyInto sets each field of the struct sv from record.
+func copyInto(record GetterSetter, sv reflect.Value) error {
+	st := sv.Type()
+	var errs []error
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		name, ok := structFieldName(sf)
+		if !ok {
+			continue
+		}
+
+		err := assignValue(sv.Field(i), record.Get(name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sf.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// structFieldName returns the record field name for sf. ok is false if sf is unexported or tagged "-".
+func structFieldName(sf reflect.StructField) (name string, ok bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+
+	if tag, ok := sf.Tag.Lookup("json"); ok {
+		name, _, _ = strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+
+	return sf.Name, true
+}
+
+// assignValue sets fv to value, converting it to the type of fv if necessary.
+func assignValue(fv reflect.Value, value any) error {
+	if value == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	vv := reflect.ValueOf(value)
+	ft := fv.Type()
+
+	switch {
+	case vv.Type().AssignableTo(ft):
+		fv.Set(vv)
+	case ft.Kind() == reflect.Pointer && vv.Kind() != reflect.Pointer:
+		ptr := reflect.New(ft.Elem())
+		err := assignValue(ptr.Elem(), value)
+		if err != nil {
+			return err
+		}
+		fv.Set(ptr)
+	case vv.Type().ConvertibleTo(ft) && convertibleKinds(vv.Kind(), ft.Kind()):
+		converted := vv.Convert(ft)
+		if isNumberKind(vv.Kind()) && converted.Convert(vv.Type()).Interface() != vv.Interface() {
+			return fmt.Errorf("%v overflows %s", value, ft)
+		}
+		fv.Set(converted)
+	default:
+		return fmt.Errorf("cannot assign %T to %s", value, ft)
+	}
+
+	return nil
+}
+
+// convertibleKinds returns true if a value of kind from can be converted to kind to without changing its meaning. Go
+// allows converting an integer to a string, but that produces a rune rather than the number's digits.
+func convertibleKinds(from, to reflect.Kind) bool {
+	if to == reflect.String {
+		return from == reflect.String
+	}
+	return true
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
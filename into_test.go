@@ -0,0 +1,80 @@
+package ensure_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type intoPerson struct {
+	Name     string     `json:"name"`
+	Age      int64      `json:"age"`
+	Nickname *string    `json:"nickname,omitempty"`
+	Status   testStatus `json:"status"`
+	Birthday *time.Time
+	Ignored  string `json:"-"`
+	Extra    any    `json:"extra"`
+	private  string
+}
+
+func TestRecordEnsurerEnsureInto(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+		r.Ensure("nickname", ensure.SingleLineString(), ensure.NilifyEmpty())
+		r.Ensure("status", ensure.SingleLineString())
+		r.Ensure("Birthday", ensure.Date())
+	})
+
+	var person intoPerson
+	err := re.EnsureInto(map[string]any{
+		"name":     " Jack ",
+		"age":      "42",
+		"nickname": "jc",
+		"status":   "active",
+		"Birthday": "1980-01-02",
+		"Ignored":  "x",
+		"extra":    []any{1, "two"},
+		"private":  "x",
+	}, &person)
+	require.NoError(t, err)
+
+	nickname := "jc"
+	birthday := time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, intoPerson{
+		Name:     "Jack",
+		Age:      42,
+		Nickname: &nickname,
+		Status:   testStatus("active"),
+		Birthday: &birthday,
+		Extra:    []any{1, "two"},
+	}, person)
+
+	person = intoPerson{Name: "Old", Nickname: &nickname}
+	err = re.EnsureInto(map[string]any{"name": "Jill", "nickname": ""}, &person)
+	require.NoError(t, err)
+	assert.Equal(t, intoPerson{Name: "Jill"}, person)
+
+	person = intoPerson{Name: "Old"}
+	err = re.EnsureInto(map[string]any{"name": "", "age": "abc"}, &person)
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Equal(t, "Old", person.Name)
+
+	var small struct {
+		Age  int8   `json:"age"`
+		Name int64  `json:"name"`
+		Tag  string `json:"tag"`
+	}
+	err = ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {}).EnsureInto(
+		map[string]any{"age": int64(300), "name": "Jack", "tag": 65}, &small)
+	assert.ErrorContains(t, err, "Age: 300 overflows int8")
+	assert.ErrorContains(t, err, "Name: cannot assign string to int64")
+	assert.ErrorContains(t, err, "Tag: cannot assign int to string")
+
+	assert.Error(t, re.EnsureInto(map[string]any{}, person))
+	assert.Error(t, re.EnsureInto(map[string]any{}, (*intoPerson)(nil)))
+}
@@ -0,0 +1,74 @@
+package ensure
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// CheckInvariants checks that e satisfies the contracts of the built-in ensurers for nil and for each of inputs. It is
+// intended for testing and fuzzing custom ensurers. e.g.
+//
+//	func FuzzSlug(f *testing.F) {
+//		f.Add("Hello World")
+//		f.Fuzz(func(t *testing.T, s string) {
+//			if err := ensure.CheckInvariants(Slug(), []any{s}); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+//
+// The invariants are:
+//
+//   - e does not panic.
+//   - nil is returned unmodified without an error.
+//   - e is idempotent: if e succeeds for an input then it also succeeds for its own result and returns that result
+//     unchanged as determined by reflect.DeepEqual.
+//
+// Ensurers such as Require and Flag deliberately do not pass nil through so they do not satisfy these invariants. All
+// violations are returned joined into one error. If there are none then nil is returned.
+func CheckInvariants(e Ensurer, inputs []any) error {
+	var errs []error
+
+	value, panicValue, err := safeEnsure(e, nil)
+	if panicValue != nil {
+		errs = append(errs, fmt.Errorf("input nil: panicked: %v", panicValue))
+	} else if err != nil {
+		errs = append(errs, fmt.Errorf("input nil: nil not passed through: %w", err))
+	} else if value != nil {
+		errs = append(errs, fmt.Errorf("input nil: nil not passed through: returned %#v", value))
+	}
+
+	for _, input := range inputs {
+		value, panicValue, err := safeEnsure(e, input)
+		if panicValue != nil {
+			errs = append(errs, fmt.Errorf("input %#v: panicked: %v", input, panicValue))
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		again, panicValue, err := safeEnsure(e, value)
+		if panicValue != nil {
+			errs = append(errs, fmt.Errorf("input %#v: not idempotent: panicked on result %#v: %v", input, value, panicValue))
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("input %#v: not idempotent: result %#v failed: %w", input, value, err))
+		} else if !reflect.DeepEqual(value, again) {
+			errs = append(errs, fmt.Errorf("input %#v: not idempotent: result %#v became %#v", input, value, again))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func safeEnsure(e Ensurer, input any) (value any, panicValue any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicValue = r
+		}
+	}()
+
+	value, err = e.Ensure(input)
+	return value, nil, err
+}
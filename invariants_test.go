@@ -0,0 +1,45 @@
+package ensure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	inputs := []any{"", " Hello  World ", "42", 42, "abc", []byte("x"), map[string]any{"a": 1}}
+
+	for i, e := range []ensure.Ensurer{
+		ensure.String(),
+		ensure.SingleLineString(),
+		ensure.CollapseSpaces(),
+		ensure.Int64(),
+		ensure.Lower(),
+		ensure.StripHTML(),
+		ensure.CamelToSnake(),
+	} {
+		assert.NoErrorf(t, ensure.CheckInvariants(e, inputs), "%d", i)
+	}
+
+	err := ensure.CheckInvariants(ensure.Require(), inputs)
+	assert.ErrorContains(t, err, "input nil: nil not passed through")
+
+	err = ensure.CheckInvariants(ensure.EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return fmt.Sprintf("[%v]", value), nil
+	}), []any{"a"})
+	assert.ErrorContains(t, err, `input "a": not idempotent: result "[a]" became "[[a]]"`)
+
+	err = ensure.CheckInvariants(ensure.EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return value.(string), nil
+	}), []any{"a", 1})
+	assert.ErrorContains(t, err, "input 1: panicked")
+	assert.NotContains(t, err.Error(), `input "a"`)
+}
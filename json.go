@@ -0,0 +1,247 @@
+package ensure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// JSONEnsurer is a Ensurer that checks that value contains valid JSON. It is returned by JSON, JSONObject, and
+// JSONArray.
+type JSONEnsurer struct {
+	kind   byte
+	decode bool
+}
+
+// JSON returns a Ensurer that returns an error unless value is a string or []byte containing valid JSON. value is
+// returned unmodified. If value is nil then nil is returned.
+func JSON() *JSONEnsurer {
+	return &JSONEnsurer{}
+}
+
+// JSONObject returns a Ensurer like JSON that also requires the JSON to be an object.
+func JSONObject() *JSONEnsurer {
+	return &JSONEnsurer{kind: '{'}
+}
+
+// JSONArray returns a Ensurer like JSON that also requires the JSON to be an array.
+func JSONArray() *JSONEnsurer {
+	return &JSONEnsurer{kind: '['}
+}
+
+// Decode returns a copy of je that returns the unmarshaled JSON instead of value so later ensurers such as Nested and
+// Slice can ensure its structure. Objects are returned as map[string]any and arrays as []any. Numbers are returned as
+// float64.
+func (je *JSONEnsurer) Decode() *JSONEnsurer {
+	newJE := *je
+	newJE.decode = true
+	return &newJE
+}
+
+func (je *JSONEnsurer) Describe() Rule {
+	switch je.kind {
+	case '{':
+		return Rule{Kind: "jsonobject"}
+	case '[':
+		return Rule{Kind: "jsonarray"}
+	default:
+		return Rule{Kind: "json"}
+	}
+}
+
+func (je *JSONEnsurer) Ensure(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var buf []byte
+	switch value := value.(type) {
+	case string:
+		buf = []byte(value)
+	case []byte:
+		buf = value
+	default:
+		return nil, NewError("not_a_string", "not a string", nil)
+	}
+
+	if !json.Valid(buf) {
+		return nil, NewError("invalid_json", "not valid JSON", nil)
+	}
+
+	trimmed := bytes.TrimLeft(buf, " \t\r\n")
+	switch je.kind {
+	case '{':
+		if trimmed[0] != '{' {
+			return nil, NewError("not_a_json_object", "not a JSON object", nil)
+		}
+	case '[':
+		if trimmed[0] != '[' {
+			return nil, NewError("not_a_json_array", "not a JSON array", nil)
+		}
+	}
+
+	if je.decode {
+		var decoded any
+		err := json.Unmarshal(buf, &decoded)
+		if err != nil {
+			return nil, NewError("invalid_json", "not valid JSON", nil)
+		}
+		return decoded, nil
+	}
+
+	return value, nil
+}
+
+// FromJSON decodes a JSON object from r into a GetterSetterMap. Numbers are decoded as json.Number instead of float64 so
+// integers too large to be exactly represented by a float64, such as 64-bit IDs, are not corrupted before they are
+// ensured. The numeric ensurers such as Int64, Decimal, and BigInt convert json.Number values exactly. Only the first
+// JSON value in r is read. If it is not an object an error is returned.
+func FromJSON(r io.Reader) (GetterSetterMap, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decodeJSONObject(decoder)
+}
+
+// FromJSONBytes is like FromJSON but decodes buf. It returns an error if buf contains anything after the object other
+// than whitespace.
+func FromJSONBytes(buf []byte) (GetterSetterMap, error) {
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	record, err := decodeJSONObject(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, NewError("invalid_json", "not valid JSON", nil)
+	}
+
+	return record, nil
+}
+
+func decodeJSONObject(decoder *json.Decoder) (GetterSetterMap, error) {
+	var decoded any
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, NewError("not_a_json_object", "not a JSON object", nil)
+	}
+
+	return GetterSetterMap(m), nil
+}
+
+// CanonicalJSON returns a Ensurer that rewrites the JSON in value in a canonical form so equal documents are byte for
+// byte identical and can be hashed or deduplicated. Insignificant whitespace is removed, object keys are sorted, and
+// numbers are written in exact decimal form without an exponent or trailing zeros, e.g. 1.50 and 15e-1 both become 1.5.
+// A number with an exponent so large or small that it would expand to more than 64 extra digits fails with
+// "invalid_json". Strings are written without escaping <, >, and &. value must be a string or []byte containing valid
+// JSON. The result has the same type as value. If value is nil then nil is returned.
+func CanonicalJSON() Ensurer {
+	return describe("canonicaljson", nil, EnsurerFunc(func(value any) (any, error) {
+		var buf []byte
+		switch value := value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			buf = []byte(value)
+		case []byte:
+			buf = value
+		default:
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(buf))
+		decoder.UseNumber()
+		var decoded any
+		err := decoder.Decode(&decoded)
+		if err != nil || !json.Valid(buf) {
+			return nil, NewError("invalid_json", "not valid JSON", nil)
+		}
+
+		canonical := &bytes.Buffer{}
+		err = writeCanonicalJSON(canonical, decoded)
+		if err != nil {
+			return nil, NewError("invalid_json", "not valid JSON", nil)
+		}
+
+		if _, ok := value.(string); ok {
+			return canonical.String(), nil
+		}
+		return canonical.Bytes(), nil
+	}))
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalJSON(buf, k)
+			if err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			err = writeCanonicalJSON(buf, value[k])
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, element := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalJSON(buf, element)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		n, err := decimal.NewFromString(value.String())
+		if err != nil {
+			return err
+		}
+		// Limit the exponent like normalizeJSONNumber so a short number such as 1e50000000 cannot expand to megabytes.
+		if n.Exponent() > 64 || n.Exponent() < -64-int32(len(value)) {
+			return fmt.Errorf("exponent of %s out of range", value)
+		}
+		buf.WriteString(n.String())
+	case string:
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(value)
+		if err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode appends a newline.
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("unexpected JSON value %T", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package ensure_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.JSON(), `{"a": 1}`, `{"a": 1}`, true},
+		{ensure.JSON(), []byte(`[1, 2]`), []byte(`[1, 2]`), true},
+		{ensure.JSON(), `"str"`, `"str"`, true},
+		{ensure.JSON(), `{"a": }`, nil, false},
+		{ensure.JSON(), ``, nil, false},
+		{ensure.JSON(), 42, nil, false},
+		{ensure.JSON(), nil, nil, true},
+		{ensure.JSONObject(), ` {"a": 1}`, ` {"a": 1}`, true},
+		{ensure.JSONObject(), `[1]`, nil, false},
+		{ensure.JSONArray(), "\n[1]", "\n[1]", true},
+		{ensure.JSONArray(), `{"a": 1}`, nil, false},
+		{ensure.JSON().Decode(), `{"a": [1, "x", null]}`, map[string]any{"a": []any{float64(1), "x", nil}}, true},
+		{ensure.JSONObject().Decode(), `{"a": true}`, map[string]any{"a": true}, true},
+		{ensure.JSONArray().Decode(), `[1]`, []any{float64(1)}, true},
+		{ensure.JSONArray().Decode(), `{}`, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestJSONDecodeNested(t *testing.T) {
+	record := ensure.GetterSetterMap{"settings": `{"theme": "dark", "font_size": "x"}`}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("settings", ensure.JSONObject().Decode(), ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("theme", ensure.AllowStrings("light", "dark"))
+			r.Ensure("font_size", ensure.Int32())
+		}))
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.Equal(t, "settings.font_size", recordErrs.FieldErrors()[0].Path.String())
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{`{"b": 1, "a": [true, null, "x"]}`, `{"a":[true,null,"x"],"b":1}`, true},
+		{[]byte(` { "z" : { "y": 2, "x": 1 } } `), []byte(`{"z":{"x":1,"y":2}}`), true},
+		{`[1.50, 15e-1, 100, 1E2, -0, 0.000]`, `[1.5,1.5,100,100,0,0]`, true},
+		{`12345678901234567890.123456789`, `12345678901234567890.123456789`, true},
+		{`"<a> & é"`, `"<a> & é"`, true},
+		{`[1e64, 1e-64]`, `[10000000000000000000000000000000000000000000000000000000000000000,` +
+			`0.0000000000000000000000000000000000000000000000000000000000000001]`, true},
+		{`{"a":1e50000000}`, nil, false},
+		{`{"a":1e-50000000}`, nil, false},
+		{`{"a": 1} {"b": 2}`, nil, false},
+		{`{"a": }`, nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.CanonicalJSON().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	record, err := ensure.FromJSON(strings.NewReader(`{"id": 9007199254740993, "count": 1.0, "big": 1e3, "price": 19.99}`))
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), record["id"])
+
+	err = ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("id", ensure.Int64())
+		r.Ensure("count", ensure.Int32())
+		r.Ensure("big", ensure.BigInt())
+		r.Ensure("price", ensure.Decimal())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), record["id"])
+	assert.Equal(t, int32(1), record["count"])
+	assert.Equal(t, big.NewInt(1000), record["big"])
+	assert.True(t, decimal.RequireFromString("19.99").Equal(record["price"].(decimal.Decimal)))
+
+	record, err = ensure.FromJSONBytes([]byte(`{"n": 1.5, "u": -1}`))
+	require.NoError(t, err)
+	_, err = ensure.Int64().Ensure(record["n"])
+	assert.Error(t, err)
+	_, err = ensure.Uint64().Ensure(record["u"])
+	assert.Error(t, err)
+
+	for i, input := range []string{`[1]`, `{"a": 1} {"b": 2}`, `{"a":`, ``} {
+		record, err := ensure.FromJSONBytes([]byte(input))
+		assert.Errorf(t, err, "%d", i)
+		assert.Nilf(t, record, "%d", i)
+	}
+
+	record, err = ensure.FromJSON(strings.NewReader(`{"a": 1} {"b": 2}`))
+	require.NoError(t, err)
+	assert.Equal(t, ensure.GetterSetterMap{"a": json.Number("1")}, record)
+}
@@ -0,0 +1,108 @@
+package ensure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONDecoder decodes a JSON object from a stream and ensures each field as soon as its value is decoded. Unlike
+// unmarshaling the whole body into a map and then calling Record, only the fields with ensurers are kept; the values of
+// all other fields are skipped. It is returned by NewJSONDecoder.
+type JSONDecoder struct {
+	fields []jsonDecoderField
+}
+
+type jsonDecoderField struct {
+	name     string
+	ensurers []Ensurer
+}
+
+// NewJSONDecoder returns a JSONDecoder with no fields. Add fields with Field.
+func NewJSONDecoder() *JSONDecoder {
+	return &JSONDecoder{}
+}
+
+// Field returns a copy of jd that ensures the top-level field name with ensurers. Objects are decoded as
+// map[string]any, arrays as []any, and numbers as json.Number, the same as FromJSON, so large integers such as IDs are
+// not rounded before they are ensured. Fields that are not in the input are ensured with a nil value after the input
+// has been read so ensurers such as Require still apply.
+func (jd *JSONDecoder) Field(name string, ensurers ...Ensurer) *JSONDecoder {
+	newJD := *jd
+	newJD.fields = make([]jsonDecoderField, 0, len(jd.fields)+1)
+	newJD.fields = append(newJD.fields, jd.fields...)
+	newJD.fields = append(newJD.fields, jsonDecoderField{name: name, ensurers: ensurers})
+	return &newJD
+}
+
+// Decode reads a single JSON object from r and returns the ensured fields as a map. If the input is not a JSON object
+// or cannot be read an error is returned. If any field fails the returned error is a *RecordErrors and the map is nil.
+func (jd *JSONDecoder) Decode(r io.Reader) (map[string]any, error) {
+	fieldsByName := make(map[string][]Ensurer, len(jd.fields))
+	for _, f := range jd.fields {
+		fieldsByName[f.name] = f.ensurers
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, NewError("not_a_json_object", "not a JSON object", nil)
+	}
+
+	record := make(GetterSetterMap, len(jd.fields))
+	rwe := &RecordWithErrors{record: record}
+	seen := make(map[string]struct{}, len(jd.fields))
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected JSON token: %v", tok)
+		}
+
+		ensurers, ok := fieldsByName[key]
+		if !ok {
+			var skip json.RawMessage
+			err := dec.Decode(&skip)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var value any
+		err = dec.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+
+		record[key] = value
+		rwe.Ensure(key, ensurers...)
+		seen[key] = struct{}{}
+	}
+
+	_, err = dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range jd.fields {
+		if _, ok := seen[f.name]; !ok {
+			rwe.Ensure(f.name, f.ensurers...)
+		}
+	}
+
+	if errs := rwe.Errors(); errs != nil {
+		return nil, &RecordErrors{tree: errs, fieldErrors: rwe.fieldErrors}
+	}
+
+	return record, nil
+}
@@ -0,0 +1,52 @@
+package ensure_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDecoder(t *testing.T) {
+	decoder := ensure.NewJSONDecoder().
+		Field("name", ensure.SingleLineString(), ensure.Require()).
+		Field("age", ensure.Int32()).
+		Field("tags", ensure.Slice[string](ensure.String()))
+
+	input := `{"name": " Jack ", "age": 42, "ignored": {"big": [1, 2, 3]}, "tags": ["a", "b"]}`
+	record, err := decoder.Decode(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Jack", "age": int32(42), "tags": []string{"a", "b"}}, record)
+
+	record, err = decoder.Decode(strings.NewReader(`{"age": "abc"}`))
+	require.Error(t, err)
+	assert.Nil(t, record)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"name"}), 1)
+	assert.Len(t, etErr.Get([]any{"age"}), 1)
+	assert.Len(t, etErr.Get([]any{"tags"}), 0)
+
+	record, err = ensure.NewJSONDecoder().
+		Field("id", ensure.Int64()).
+		Field("ids", ensure.Slice[int64](ensure.Int64())).
+		Field("raw").
+		Decode(strings.NewReader(`{"id": 9007199254740993, "ids": [9223372036854775807], "raw": 1.50}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":  int64(9007199254740993),
+		"ids": []int64{9223372036854775807},
+		"raw": json.Number("1.50"),
+	}, record)
+
+	for i, input := range []string{`[1, 2]`, `{"name": "Jack"`, `{"name": }`, ``} {
+		record, err := decoder.Decode(strings.NewReader(input))
+		assert.Errorf(t, err, "%d", i)
+		assert.Nilf(t, record, "%d", i)
+	}
+}
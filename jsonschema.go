@@ -0,0 +1,347 @@
+package ensure
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// JSONSchema returns a draft 2020-12 JSON Schema of the records accepted by re so the same definition can drive
+// client-side validation and API documentation. It is intended to be marshaled with encoding/json. e.g.
+//
+//	buf, err := json.Marshal(PersonEnsurer.JSONSchema())
+//
+// The schema is found by running each phase of re against an empty record. Ensure and EnsureAs record the field and
+// ensurers they are given instead of ensuring the field, so a phase must not depend on the values of the record to
+// decide which fields to ensure. The schema of a field is derived from the built-in ensurers in its chain: the type and
+// format, minLength and maxLength, minimum and maximum, enum, and pattern. Nested, Slice, Map, AnyOf, and All are
+// described recursively. A field with Require or NotNil is required. Other fields also allow null. The ensurers of
+// EnsureIf and RequireIf only apply if their condition is true, so they are omitted but their field is a property.
+// Label sets the title of a field. Other ensurers, such as those written outside this package or When, are omitted,
+// so the schema may accept some values that re rejects. Patterns are in Go syntax, which is mostly compatible with the
+// ECMA-262 syntax used by JSON Schema.
+func (re *RecordEnsurer) JSONSchema() map[string]any {
+	schema := re.objectSchema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	return schema
+}
+
+// objectSchema returns the JSON Schema of an object with the fields of re.
+func (re *RecordEnsurer) objectSchema() map[string]any {
+	properties := make(map[string]any)
+	var required []string
+
+	for _, df := range re.describeRecord() {
+		sb := &schemaBuilder{}
+		for _, e := range df.ensurers {
+			sb.add(e)
+		}
+
+		s := sb.schema()
+		if df.label != "" {
+			s["title"] = df.label
+		}
+		properties[df.name] = s
+
+		if sb.required {
+			required = append(required, df.name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// schemaBuilder accumulates the JSON Schema of a chain of ensurers.
+type schemaBuilder struct {
+	typ              string
+	format           string
+	contentMediaType string
+	required         bool
+	nonEmpty         bool
+
+	minLen *int
+	maxLen *int
+
+	minimum          *decimal.Decimal
+	maximum          *decimal.Decimal
+	exclusiveMinimum *decimal.Decimal
+	exclusiveMaximum *decimal.Decimal
+
+	enum     []any
+	patterns []string
+
+	object               map[string]any
+	items                *schemaBuilder
+	additionalProperties *schemaBuilder
+	anyOf                []*schemaBuilder
+}
+
+// add adds the constraints of e. e is ignored if it does not describe itself.
+func (sb *schemaBuilder) add(e Ensurer) {
+	d, ok := e.(Describer)
+	if !ok {
+		return
+	}
+	r := d.Describe()
+	if conditional, _ := r.Params["if"].(bool); conditional {
+		return
+	}
+
+	switch r.Kind {
+	case "string", "singleline", "multiline", "collapsespaces", "lower", "upper", "titlecase":
+		sb.typ = "string"
+	case "uuid":
+		sb.typ, sb.format = "string", "uuid"
+	case "url":
+		sb.typ, sb.format = "string", "uri"
+	case "date":
+		sb.typ = "string"
+		if formats, _ := r.Params["formats"].([]string); len(formats) == 1 && formats[0] == "2006-01-02" {
+			sb.format = "date"
+		}
+	case "time":
+		if unixSeconds, _ := r.Params["unix_seconds"].(bool); unixSeconds {
+			break
+		}
+		sb.typ = "string"
+		formats, _ := r.Params["formats"].([]string)
+		for _, f := range formats {
+			if f == time.RFC3339 || f == time.RFC3339Nano {
+				sb.format = "date-time"
+			}
+		}
+	case "json", "jsonobject", "jsonarray":
+		sb.typ, sb.contentMediaType = "string", "application/json"
+	case "bool", "boolnullable":
+		sb.typ = "boolean"
+	case "int", "int64", "bigint":
+		sb.typ = "integer"
+	case "int8":
+		sb.integerRange(math.MinInt8, math.MaxInt8)
+	case "int16":
+		sb.integerRange(math.MinInt16, math.MaxInt16)
+	case "int32":
+		sb.integerRange(math.MinInt32, math.MaxInt32)
+	case "uint8":
+		sb.integerRange(0, math.MaxUint8)
+	case "uint16":
+		sb.integerRange(0, math.MaxUint16)
+	case "uint32":
+		sb.integerRange(0, math.MaxUint32)
+	case "contentlength":
+		sb.integerRange(0, r.Params["max"].(int64))
+	case "uint64":
+		sb.typ = "integer"
+		sb.minimum = tighterBound(sb.minimum, 0, true)
+	case "float32", "float64", "decimal":
+		sb.typ = "number"
+	case "require":
+		sb.required, sb.nonEmpty = true, true
+	case "notnil":
+		sb.required = true
+	case "minlen":
+		n := r.Params["min"].(int)
+		sb.minLen = &n
+	case "maxlen":
+		n := r.Params["max"].(int)
+		sb.maxLen = &n
+	case "min":
+		sb.minimum = tighterBound(sb.minimum, r.Params["min"], true)
+	case "max":
+		sb.maximum = tighterBound(sb.maximum, r.Params["max"], false)
+	case "gt":
+		sb.exclusiveMinimum = tighterBound(sb.exclusiveMinimum, r.Params["greater_than"], true)
+	case "lt":
+		sb.exclusiveMaximum = tighterBound(sb.exclusiveMaximum, r.Params["less_than"], false)
+	case "between":
+		sb.minimum = tighterBound(sb.minimum, r.Params["min"], true)
+		sb.maximum = tighterBound(sb.maximum, r.Params["max"], false)
+	case "betweenexclusive":
+		sb.exclusiveMinimum = tighterBound(sb.exclusiveMinimum, r.Params["min"], true)
+		sb.exclusiveMaximum = tighterBound(sb.exclusiveMaximum, r.Params["max"], false)
+	case "oneof", "allowstrings", "enum":
+		sb.enum = schemaValues(r.Params["allowed"])
+	case "prefix":
+		sb.patterns = append(sb.patterns, "^"+regexp.QuoteMeta(r.Params["prefix"].(string)))
+	case "suffix":
+		sb.patterns = append(sb.patterns, regexp.QuoteMeta(r.Params["suffix"].(string))+"$")
+	case "contains":
+		sb.patterns = append(sb.patterns, regexp.QuoteMeta(r.Params["substring"].(string)))
+	case "match":
+		sb.patterns = append(sb.patterns, r.Params["pattern"].(string))
+		// A string of at most n bytes has at most n characters.
+		if n, ok := r.Params["max_bytes"].(int); ok && (sb.maxLen == nil || n < *sb.maxLen) {
+			sb.maxLen = &n
+		}
+	case "nested":
+		sb.typ = "object"
+		sb.object = r.Params["record"].(*RecordEnsurer).objectSchema()
+	case "slice":
+		sb.typ = "array"
+		sb.items = &schemaBuilder{}
+		sb.items.add(r.Params["element"].(Ensurer))
+	case "map":
+		sb.typ = "object"
+		sb.additionalProperties = &schemaBuilder{}
+		sb.additionalProperties.add(r.Params["value"].(Ensurer))
+	case "all", "ifnotnil", "external", "retry", "breaker", "prefetch":
+		for _, e := range r.Params["ensurers"].([]Ensurer) {
+			sb.add(e)
+		}
+	case "anyof":
+		sb.anyOf = nil
+		for _, e := range r.Params["ensurers"].([]Ensurer) {
+			alternative := &schemaBuilder{}
+			alternative.add(e)
+			sb.anyOf = append(sb.anyOf, alternative)
+		}
+	}
+}
+
+// integerRange sets the type to integer between min and max.
+func (sb *schemaBuilder) integerRange(min, max int64) {
+	sb.typ = "integer"
+	sb.minimum = tighterBound(sb.minimum, min, true)
+	sb.maximum = tighterBound(sb.maximum, max, false)
+}
+
+// tighterBound returns the tighter of current and x. lower is true for a lower bound.
+func tighterBound(current *decimal.Decimal, x any, lower bool) *decimal.Decimal {
+	dx, ok := tryDecimal(x)
+	if !ok {
+		return current
+	}
+
+	if current != nil && (lower && current.GreaterThan(dx) || !lower && current.LessThan(dx)) {
+		return current
+	}
+	return &dx
+}
+
+// schemaValues converts the slice allowed to values that marshal as JSON strings, numbers, and booleans.
+func schemaValues(allowed any) []any {
+	rv := reflect.ValueOf(allowed)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+
+	values := make([]any, rv.Len())
+	for i := range values {
+		v := rv.Index(i)
+		switch v.Kind() {
+		case reflect.String:
+			values[i] = v.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values[i] = v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values[i] = v.Uint()
+		case reflect.Float32, reflect.Float64:
+			values[i] = v.Float()
+		case reflect.Bool:
+			values[i] = v.Bool()
+		default:
+			values[i] = v.Interface()
+		}
+	}
+
+	return values
+}
+
+// schema returns the JSON Schema of the chain.
+func (sb *schemaBuilder) schema() map[string]any {
+	s := make(map[string]any)
+
+	for k, v := range sb.object {
+		s[k] = v
+	}
+
+	if sb.typ != "" {
+		if sb.required {
+			s["type"] = sb.typ
+		} else {
+			s["type"] = []string{sb.typ, "null"}
+		}
+	}
+	if sb.format != "" {
+		s["format"] = sb.format
+	}
+	if sb.contentMediaType != "" {
+		s["contentMediaType"] = sb.contentMediaType
+	}
+
+	minLen := sb.minLen
+	if sb.nonEmpty && (sb.typ == "" || sb.typ == "string") && (minLen == nil || *minLen < 1) {
+		one := 1
+		minLen = &one
+	}
+	minKeyword, maxKeyword := "minLength", "maxLength"
+	switch sb.typ {
+	case "array":
+		minKeyword, maxKeyword = "minItems", "maxItems"
+	case "object":
+		minKeyword, maxKeyword = "minProperties", "maxProperties"
+	}
+	if minLen != nil {
+		s[minKeyword] = *minLen
+	}
+	if sb.maxLen != nil {
+		s[maxKeyword] = *sb.maxLen
+	}
+
+	for keyword, bound := range map[string]*decimal.Decimal{
+		"minimum":          sb.minimum,
+		"maximum":          sb.maximum,
+		"exclusiveMinimum": sb.exclusiveMinimum,
+		"exclusiveMaximum": sb.exclusiveMaximum,
+	} {
+		if bound != nil {
+			s[keyword] = json.Number(bound.String())
+		}
+	}
+
+	if sb.enum != nil {
+		enum := sb.enum
+		if !sb.required {
+			enum = append(enum[:len(enum):len(enum)], nil)
+		}
+		s["enum"] = enum
+	}
+
+	switch len(sb.patterns) {
+	case 0:
+	case 1:
+		s["pattern"] = sb.patterns[0]
+	default:
+		allOf := make([]any, len(sb.patterns))
+		for i, p := range sb.patterns {
+			allOf[i] = map[string]any{"pattern": p}
+		}
+		s["allOf"] = allOf
+	}
+
+	if sb.items != nil {
+		s["items"] = sb.items.schema()
+	}
+	if sb.additionalProperties != nil {
+		s["additionalProperties"] = sb.additionalProperties.schema()
+	}
+	if sb.anyOf != nil {
+		anyOf := make([]any, len(sb.anyOf))
+		for i, alternative := range sb.anyOf {
+			alternative.required = sb.required
+			anyOf[i] = alternative.schema()
+		}
+		s["anyOf"] = anyOf
+	}
+
+	return s
+}
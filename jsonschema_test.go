@@ -0,0 +1,95 @@
+package ensure_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerJSONSchema(t *testing.T) {
+	called := false
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Label("name", "Full name")
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(100))
+		r.Ensure("age", ensure.Int32(), ensure.Between(0, 150))
+		r.Ensure("color", ensure.Lower(), ensure.OneOf("red", "green"))
+		r.RequireIf(ensure.FieldEquals("color", "red"), "shade")
+		r.Ensure("code", ensure.Match(regexp.MustCompile(`^[A-Z]+$`)).MaxInputLen(20), ensure.HasPrefix("A."),
+			ensure.NotNil())
+		r.Ensure("id", ensure.UUID(), ensure.NotNil())
+		r.Ensure("website", ensure.URL())
+		r.Ensure("born", ensure.Date())
+		r.Ensure("tags", ensure.Slice[string](ensure.All(ensure.SingleLineString(), ensure.Require())), ensure.MinLen(1))
+		r.Ensure("address", ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("zip", ensure.SingleLineString(), ensure.Require())
+		}))
+		r.Ensure("ref", ensure.AnyOf(ensure.UUID(), ensure.Int64()))
+		r.EnsureAs("firstName", "first_name", ensure.String())
+		r.Ensure("custom", ensure.EnsurerFunc(func(value any) (any, error) {
+			called = true
+			return value, nil
+		}))
+	}).Then(func(r *ensure.RecordWithErrors) {
+		r.Ensure("price", ensure.Decimal(), ensure.GreaterThan("0.5"))
+		r.Ensure("age", ensure.LessThanOrEqual(200))
+	})
+
+	buf, err := json.Marshal(re.JSONSchema())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name", "code", "id"],
+		"properties": {
+			"name": {"title": "Full name", "type": "string", "minLength": 1, "maxLength": 100},
+			"age": {"type": ["integer", "null"], "minimum": 0, "maximum": 150},
+			"color": {"type": ["string", "null"], "enum": ["red", "green", null]},
+			"shade": {},
+			"code": {"maxLength": 20, "allOf": [{"pattern": "^[A-Z]+$"}, {"pattern": "^A\\."}]},
+			"id": {"type": "string", "format": "uuid"},
+			"website": {"type": ["string", "null"], "format": "uri"},
+			"born": {"type": ["string", "null"], "format": "date"},
+			"tags": {
+				"type": ["array", "null"],
+				"minItems": 1,
+				"items": {"type": "string", "minLength": 1}
+			},
+			"address": {
+				"type": ["object", "null"],
+				"properties": {"zip": {"type": "string", "minLength": 1}},
+				"required": ["zip"]
+			},
+			"ref": {"anyOf": [{"type": ["string", "null"], "format": "uuid"}, {"type": ["integer", "null"]}]},
+			"firstName": {"type": ["string", "null"]},
+			"custom": {},
+			"price": {"type": ["number", "null"], "exclusiveMinimum": 0.5}
+		}
+	}`, string(buf))
+
+	assert.False(t, called)
+}
+
+func TestForStructJSONSchema(t *testing.T) {
+	type item struct {
+		Size  string `json:"size" ensure:"singleline,require,oneof=S M L"`
+		Count int16  `json:"count" ensure:"int16,min=1"`
+	}
+
+	buf, err := json.Marshal(ensure.ForStruct[item]().JSONSchema())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["size"],
+		"properties": {
+			"size": {"type": "string", "minLength": 1, "enum": ["S", "M", "L"]},
+			"count": {"type": ["integer", "null"], "minimum": 1, "maximum": 32767}
+		}
+	}`, string(buf))
+}
@@ -0,0 +1,135 @@
+package ensure
+
+import "fmt"
+
+// Issue is a problem with the definition of a RecordEnsurer found by RecordEnsurer.Lint.
+type Issue struct {
+	// Field is the path of the field such as "items[].price". "[]" is any element of a slice and ".*" is any value of
+	// a map.
+	Field string
+
+	// Code identifies the kind of issue such as "length_on_number".
+	Code string
+
+	// Message describes the issue.
+	Message string
+}
+
+// String returns the issue formatted like "age: maxlen after int32 always fails".
+func (i Issue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+// numericKinds are the kinds of the ensurers that convert to a number.
+var numericKinds = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true, "bigint": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "decimal": true,
+}
+
+// Lint returns the issues with the chains of ensurers of re. It is intended to be called in a test so mistakes in a
+// definition are found without a record that triggers them. The issues found are:
+//
+//   - "length_on_number": MinLen or MaxLen after a numeric converter. It always fails.
+//   - "length_before_number": MinLen or MaxLen before a numeric converter. It checks the length of the input rather
+//     than the number. Use MaxInputBytes to limit the input or Max to limit the number.
+//   - "require_in_ifnotnil": Require or NotNil inside IfNotNil. It never fails.
+//   - "ifnotnil_after_require": IfNotNil after Require or NotNil. The value is never nil.
+//   - "nilifyempty_after_require": NilifyEmpty after Require. It turns an empty slice or map that Require accepted into
+//     nil. Put NilifyEmpty first.
+//   - "conflicting_bounds": a minimum length or value greater than the maximum. It always fails.
+//
+// Like Rules, the chains are found by running each phase of re against an empty record. Nested records, the elements
+// of Slice, and the values of Map are linted recursively. Ensurers that do not describe themselves are ignored.
+func (re *RecordEnsurer) Lint() []Issue {
+	l := &linter{}
+	l.record("", re)
+	return l.issues
+}
+
+// linter collects the issues of a RecordEnsurer.
+type linter struct {
+	issues []Issue
+}
+
+func (l *linter) add(field, code, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// record lints the fields of re. prefix is prepended to the name of each field.
+func (l *linter) record(prefix string, re *RecordEnsurer) {
+	for _, df := range re.describeRecord() {
+		l.chain(prefix+df.name, df.ensurers, false)
+
+		sb := &schemaBuilder{}
+		for _, e := range df.ensurers {
+			sb.add(e)
+		}
+		if sb.minLen != nil && sb.maxLen != nil && *sb.minLen > *sb.maxLen {
+			l.add(prefix+df.name, "conflicting_bounds", "minimum length %d is greater than maximum length %d",
+				*sb.minLen, *sb.maxLen)
+		}
+		if sb.minimum != nil && sb.maximum != nil && sb.minimum.GreaterThan(*sb.maximum) {
+			l.add(prefix+df.name, "conflicting_bounds", "minimum %s is greater than maximum %s", sb.minimum, sb.maximum)
+		}
+	}
+}
+
+// chain lints a chain of ensurers of field. ifNotNil is true if the chain is inside IfNotNil.
+func (l *linter) chain(field string, ensurers []Ensurer, ifNotNil bool) {
+	var numeric, length, required string
+
+	for _, r := range flattenRules(ensurers) {
+		switch {
+		case numericKinds[r.Kind]:
+			if length != "" {
+				l.add(field, "length_before_number", "%s before %s checks the length of the input", length, r.Kind)
+			}
+			numeric = r.Kind
+		case r.Kind == "minlen" || r.Kind == "maxlen":
+			if numeric != "" {
+				l.add(field, "length_on_number", "%s after %s always fails", r.Kind, numeric)
+			}
+			length = r.Kind
+		case r.Kind == "require" || r.Kind == "notnil":
+			if ifNotNil {
+				l.add(field, "require_in_ifnotnil", "%s inside ifnotnil never fails", r.Kind)
+			}
+			required = r.Kind
+		case r.Kind == "nilifyempty":
+			if required != "" {
+				l.add(field, "nilifyempty_after_require", "nilifyempty after %s can make the value nil", required)
+			}
+		case r.Kind == "ifnotnil":
+			if required != "" {
+				l.add(field, "ifnotnil_after_require", "ifnotnil after %s is always applied", required)
+			}
+			l.chain(field, r.Params["ensurers"].([]Ensurer), true)
+		case r.Kind == "anyof":
+			for _, e := range r.Params["ensurers"].([]Ensurer) {
+				l.chain(field, []Ensurer{e}, ifNotNil)
+			}
+		case r.Kind == "slice":
+			l.chain(field+"[]", []Ensurer{r.Params["element"].(Ensurer)}, false)
+		case r.Kind == "map":
+			l.chain(field+".*", []Ensurer{r.Params["value"].(Ensurer)}, false)
+		case r.Kind == "nested":
+			l.record(field+".", r.Params["record"].(*RecordEnsurer))
+		}
+	}
+}
+
+// flattenRules returns the rules of ensurers with the ensurers of All and of wrappers such as Retry in their place.
+func flattenRules(ensurers []Ensurer) []Rule {
+	var rules []Rule
+	for _, e := range ensurers {
+		r := Describe(e)
+		switch r.Kind {
+		case "all", "external", "retry", "breaker", "prefetch":
+			rules = append(rules, flattenRules(r.Params["ensurers"].([]Ensurer))...)
+		default:
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,43 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordEnsurerLint(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(100))
+		r.Ensure("amount", ensure.MaxInputBytes(64), ensure.Decimal(), ensure.Between(1, 100))
+		r.Ensure("age", ensure.Int32(), ensure.MaxLen(3))
+		r.Ensure("zip", ensure.MaxLen(5), ensure.Int32())
+		r.Ensure("nickname", ensure.IfNotNil(ensure.SingleLineString(), ensure.Require()))
+		r.Ensure("code", ensure.NotNil(), ensure.IfNotNil(ensure.Lower()))
+		r.Ensure("tags", ensure.Slice[string](ensure.SingleLineString()), ensure.Require(), ensure.NilifyEmpty())
+		r.Ensure("title", ensure.SingleLineString(), ensure.MinLen(10), ensure.MaxLen(5))
+		r.Ensure("items", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("price", ensure.All(ensure.Decimal(), ensure.GreaterThanOrEqual(10)), ensure.LessThanOrEqual(5))
+		})))
+		r.Ensure("metadata", ensure.Map[string, any](ensure.String(), ensure.All(ensure.Float64(), ensure.MinLen(1))))
+	})
+
+	assert.Equal(t, []ensure.Issue{
+		{Field: "age", Code: "length_on_number", Message: "maxlen after int32 always fails"},
+		{Field: "zip", Code: "length_before_number", Message: "maxlen before int32 checks the length of the input"},
+		{Field: "nickname", Code: "require_in_ifnotnil", Message: "require inside ifnotnil never fails"},
+		{Field: "code", Code: "ifnotnil_after_require", Message: "ifnotnil after notnil is always applied"},
+		{Field: "tags", Code: "nilifyempty_after_require", Message: "nilifyempty after require can make the value nil"},
+		{Field: "title", Code: "conflicting_bounds", Message: "minimum length 10 is greater than maximum length 5"},
+		{Field: "items[].price", Code: "conflicting_bounds", Message: "minimum 10 is greater than maximum 5"},
+		{Field: "metadata.*", Code: "length_on_number", Message: "minlen after float64 always fails"},
+	}, re.Lint())
+
+	assert.Equal(t, "age: maxlen after int32 always fails", re.Lint()[0].String())
+
+	clean := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.NilifyEmpty(), ensure.Require(), ensure.MaxLen(100))
+	})
+	assert.Empty(t, clean.Lint())
+}
@@ -0,0 +1,56 @@
+package ensure
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Locale returns a Ensurer that converts value to a canonical language.Tag. value may be a BCP 47 language tag or a
+// POSIX style locale such as "en_US". If value is nil or a blank string nil is returned. If value is not a string or
+// language.Tag then an error is returned.
+func Locale() Ensurer {
+	return describe("locale", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		switch value := value.(type) {
+		case language.Tag:
+			return value, nil
+		case string:
+			tag, err := language.Parse(value)
+			if err != nil {
+				return nil, NewError("invalid_locale", "not a valid locale", nil)
+			}
+			return tag, nil
+		}
+
+		return nil, NewError("invalid_locale", "not a valid locale", nil)
+	}))
+}
+
+// AcceptLanguage returns a Ensurer that parses value as an Accept-Language header value such as
+// "fr-CH, fr;q=0.9, en;q=0.8" and converts it to a []language.Tag ordered by descending quality. Tags with a quality of
+// 0 are omitted. If value is nil or a blank string nil is returned. If value is not a string then an error is returned.
+func AcceptLanguage() Ensurer {
+	return describe("acceptlanguage", nil, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(s)
+		if err != nil {
+			return nil, NewError("invalid_locale", "not a valid Accept-Language value", nil)
+		}
+
+		return tags, nil
+	}))
+}
@@ -0,0 +1,52 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"en-US", language.MustParse("en-US"), true},
+		{" en_us ", language.MustParse("en-US"), true},
+		{"zh-hant-tw", language.MustParse("zh-Hant-TW"), true},
+		{language.French, language.French, true},
+		{"not a locale", nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Locale().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"en;q=0.8, fr-CH, fr;q=0.9", []language.Tag{language.MustParse("fr-CH"), language.French, language.English}, true},
+		{"de", []language.Tag{language.German}, true},
+		{"en;q=abc", nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.AcceptLanguage().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
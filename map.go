@@ -0,0 +1,89 @@
+package ensure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type mapEntryError struct {
+	Key string
+	Err error
+}
+
+type mapEntryErrors []mapEntryError
+
+func (e mapEntryErrors) Error() string {
+	sb := &strings.Builder{}
+	for i, ee := range e {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "Key %s: %v", ee.Key, ee.Err)
+	}
+	return sb.String()
+}
+
+// Map returns a Ensurer that converts value to a map[K]V by ensuring each key with keyEnsurer and each value with
+// valueEnsurer. value must be a map[K]V, map[string]any, or GetterSetterMap. A map[K]V is returned unmodified. When
+// used with RecordWithErrors.Ensure, errors are added with a path for each key such as "metadata.color". If value is nil
+// then nil is returned.
+func Map[K comparable, V any](keyEnsurer, valueEnsurer Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		var m map[string]any
+		switch value := value.(type) {
+		case map[K]V:
+			return value, nil
+		case map[string]any:
+			m = value
+		case GetterSetterMap:
+			m = value
+		default:
+			return nil, NewError("not_a_map", "cannot convert to map", nil)
+		}
+
+		result := make(map[K]V, len(m))
+		var entryErrs mapEntryErrors
+		for key, element := range m {
+			entryCtx := warningContext(ctx, key)
+			k, err := ensureOrWarn(entryCtx, keyEnsurer, key)
+			if err != nil {
+				entryErrs = append(entryErrs, mapEntryError{Key: key, Err: err})
+				continue
+			}
+			typedKey, ok := k.(K)
+			if !ok {
+				var zero K
+				entryErrs = append(entryErrs, mapEntryError{Key: key, Err: NewError("invalid_type", fmt.Sprintf("key not a %T", zero), map[string]any{"type": fmt.Sprintf("%T", zero)})})
+				continue
+			}
+
+			v, err := ensureOrWarn(entryCtx, valueEnsurer, element)
+			if err != nil {
+				entryErrs = append(entryErrs, mapEntryError{Key: key, Err: err})
+				continue
+			}
+			typedValue, ok := v.(V)
+			if !ok && (v != nil || any(typedValue) != nil) {
+				entryErrs = append(entryErrs, mapEntryError{Key: key, Err: NewError("invalid_type", fmt.Sprintf("not a %T", typedValue), map[string]any{"type": fmt.Sprintf("%T", typedValue)})})
+				continue
+			}
+
+			result[typedKey] = typedValue
+		}
+
+		if entryErrs != nil {
+			sort.Slice(entryErrs, func(i, j int) bool { return entryErrs[i].Key < entryErrs[j].Key })
+			return nil, entryErrs
+		}
+
+		return result, nil
+	})
+
+	return describe("map", map[string]any{"key": keyEnsurer, "value": valueEnsurer}, ensurer)
+}
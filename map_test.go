@@ -0,0 +1,52 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Map[string, int32](ensure.String(), ensure.Int32()), map[string]any{"a": "1", "b": 2}, map[string]int32{"a": 1, "b": 2}, true},
+		{ensure.Map[string, int32](ensure.String(), ensure.Int32()), map[string]int32{"a": 1}, map[string]int32{"a": 1}, true},
+		{ensure.Map[string, int32](ensure.String(), ensure.Int32()), map[string]any{"a": "x"}, nil, false},
+		{ensure.Map[int64, string](ensure.Int64(), ensure.String()), ensure.GetterSetterMap{"10": "ten"}, map[int64]string{10: "ten"}, true},
+		{ensure.Map[int64, string](ensure.Int64(), ensure.String()), map[string]any{"ten": "ten"}, nil, false},
+		{ensure.Map[string, any](ensure.String(), ensure.String()), map[string]any{"a": nil}, map[string]any{"a": nil}, true},
+		{ensure.Map[string, string](ensure.String(), ensure.Int32()), map[string]any{"a": "1"}, nil, false},
+		{ensure.Map[string, int32](ensure.String(), ensure.Int32()), map[string]any{"a": ""}, nil, false},
+		{ensure.Map[string, string](ensure.String(), ensure.String()), []any{"a"}, nil, false},
+		{ensure.Map[string, string](ensure.String(), ensure.String()), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
+
+func TestMapRecordErrorPaths(t *testing.T) {
+	record := ensure.GetterSetterMap{
+		"metadata": map[string]any{"size": "10", "color": "x", "weight": "heavy"},
+	}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("metadata", ensure.Map[string, int32](ensure.String(), ensure.Int32()))
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	fieldErrs := recordErrs.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, ensure.Path{"metadata", "color"}, fieldErrs[0].Path)
+	assert.Equal(t, "metadata.color", fieldErrs[0].Path.String())
+	assert.Equal(t, "metadata.weight", fieldErrs[1].Path.String())
+}
@@ -0,0 +1,315 @@
+package ensure
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MarkdownPolicy controls how SafeMarkdown handles unsafe content.
+type MarkdownPolicy int
+
+const (
+	// MarkdownStrip removes raw HTML and replaces unsafe link destinations with "#".
+	MarkdownStrip MarkdownPolicy = iota
+
+	// MarkdownReject returns an error if the source contains raw HTML or unsafe links.
+	MarkdownReject
+)
+
+var markdownUnsafeSchemes = []string{"javascript:", "vbscript:", "data:"}
+
+var (
+	markdownFenceRegexp         = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	markdownReferenceDefRegexp  = regexp.MustCompile(`(?m)^( {0,3}\[[^\]]+\]:[ \t]*)(<[^>\n]*>|\S+)`)
+	markdownAutolinkRegexp      = regexp.MustCompile(`^<[A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*>`)
+	markdownEmailAutolinkRegexp = regexp.MustCompile(`^<[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)*>`)
+	markdownInlineHTMLRegexp    = regexp.MustCompile(`^<(?:[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?|/[A-Za-z][A-Za-z0-9-]*\s*|!--[\s\S]*?--|![A-Za-z][^>]*|\?[\s\S]*?\?|!\[CDATA\[[\s\S]*?\]\])>`)
+	markdownTagStartRegexp      = regexp.MustCompile(`^<[A-Za-z!/?]`)
+	markdownBlockHTMLRegexp     = regexp.MustCompile(`(?im)^ {0,3}</?(?:address|article|aside|base|basefont|blockquote|body|caption|center|col|colgroup|dd|details|dialog|dir|div|dl|dt|fieldset|figcaption|figure|footer|form|frame|frameset|h[1-6]|head|header|hr|html|iframe|legend|li|link|main|menu|menuitem|nav|noframes|ol|optgroup|option|p|param|pre|script|search|section|style|summary|table|tbody|td|textarea|tfoot|th|thead|title|tr|track|ul)(?:\s[^>\n]*)?/?(?:>|$)`)
+)
+
+// SafeMarkdown returns a Ensurer that checks markdown source for raw HTML and for links and images whose destination
+// uses an unsafe scheme such as javascript:. Depending on policy, unsafe content is either stripped or causes an
+// error. A "<" that starts what could be a tag but is not well-formed HTML, such as "<img src=x", is also unsafe and
+// is stripped by entity-encoding it as "&lt;". Content inside code spans and fenced code blocks is left untouched. If
+// value is nil then nil is returned. If value is not a string then an error is returned.
+func SafeMarkdown(policy MarkdownPolicy) Ensurer {
+	return describe("safemarkdown", map[string]any{"policy": policy}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		if policy == MarkdownReject {
+			sanitizer := &markdownSanitizer{}
+			sanitizer.sanitize(s)
+			if unsafe := sanitizer.unsafe; unsafe != "" {
+				return nil, NewError("unsafe_markdown", "contains "+unsafe, map[string]any{"content": unsafe})
+			}
+			return s, nil
+		}
+
+		// Removing content can join the text around it into new HTML such as "<scr<b>ipt>" so strip until nothing
+		// changes.
+		for {
+			sanitized := (&markdownSanitizer{strip: true}).sanitize(s)
+			if sanitized == s {
+				return s, nil
+			}
+			s = sanitized
+		}
+	}))
+}
+
+type markdownSanitizer struct {
+	strip  bool
+	unsafe string
+}
+
+func (ms *markdownSanitizer) flag(kind string) {
+	if ms.unsafe == "" {
+		ms.unsafe = kind
+	}
+}
+
+// sanitize splits s into fenced code blocks, which are copied unchanged, and everything else, which is sanitized.
+func (ms *markdownSanitizer) sanitize(s string) string {
+	sb := &strings.Builder{}
+	chunk := &strings.Builder{}
+	var fence string
+
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if fence != "" {
+			sb.WriteString(line)
+			if m := markdownFenceRegexp.FindStringSubmatch(line); m != nil && m[1][0] == fence[0] && len(m[1]) >= len(fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		if m := markdownFenceRegexp.FindStringSubmatch(line); m != nil {
+			sb.WriteString(ms.sanitizeChunk(chunk.String()))
+			chunk.Reset()
+			sb.WriteString(line)
+			fence = m[1]
+			continue
+		}
+
+		chunk.WriteString(line)
+	}
+	sb.WriteString(ms.sanitizeChunk(chunk.String()))
+
+	return sb.String()
+}
+
+func (ms *markdownSanitizer) sanitizeChunk(s string) string {
+	s = markdownBlockHTMLRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		ms.flag("raw HTML")
+		if ms.strip {
+			return ""
+		}
+		return match
+	})
+
+	s = markdownReferenceDefRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		m := markdownReferenceDefRegexp.FindStringSubmatch(match)
+		if unsafeMarkdownURL(m[2]) {
+			ms.flag("unsafe link")
+			if ms.strip {
+				return m[1] + "#"
+			}
+		}
+		return match
+	})
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\\':
+			end := i + 2
+			if end > len(s) {
+				end = len(s)
+			}
+			sb.WriteString(s[i:end])
+			i = end
+		case '`':
+			n := 0
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			end := markdownCodeSpanEnd(s, i+n, n)
+			if end < 0 {
+				end = i + n
+			}
+			sb.WriteString(s[i:end])
+			i = end
+		case '<':
+			if m := markdownAutolinkRegexp.FindString(s[i:]); m != "" {
+				if unsafeMarkdownURL(m[1 : len(m)-1]) {
+					ms.flag("unsafe link")
+					if ms.strip {
+						i += len(m)
+						continue
+					}
+				}
+				sb.WriteString(m)
+				i += len(m)
+			} else if m := markdownEmailAutolinkRegexp.FindString(s[i:]); m != "" {
+				sb.WriteString(m)
+				i += len(m)
+			} else if m := markdownInlineHTMLRegexp.FindString(s[i:]); m != "" {
+				ms.flag("raw HTML")
+				if !ms.strip {
+					sb.WriteString(m)
+				}
+				i += len(m)
+			} else if markdownTagStartRegexp.MatchString(s[i:]) {
+				// Not a well-formed tag, but a browser or a lenient renderer may still treat it as the start of one.
+				ms.flag("raw HTML")
+				if ms.strip {
+					sb.WriteString("&lt;")
+				} else {
+					sb.WriteByte('<')
+				}
+				i++
+			} else {
+				sb.WriteByte('<')
+				i++
+			}
+		case ']':
+			if i+1 < len(s) && s[i+1] == '(' {
+				destStart, destEnd, end := markdownLinkDestination(s, i+2)
+				if end > 0 {
+					sb.WriteString(s[i:destStart])
+					if unsafeMarkdownURL(s[destStart:destEnd]) {
+						ms.flag("unsafe link")
+						if ms.strip {
+							sb.WriteString("#")
+						} else {
+							sb.WriteString(s[destStart:destEnd])
+						}
+					} else {
+						sb.WriteString(s[destStart:destEnd])
+					}
+					sb.WriteString(s[destEnd:end])
+					i = end
+					continue
+				}
+			}
+			sb.WriteByte(']')
+			i++
+		default:
+			sb.WriteByte(s[i])
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+// markdownCodeSpanEnd returns the index just past the backtick run of exactly n backticks that closes a code span
+// starting at start, or -1 if there is none.
+func markdownCodeSpanEnd(s string, start, n int) int {
+	for i := start; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		runStart := i
+		for i < len(s) && s[i] == '`' {
+			i++
+		}
+		if i-runStart == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// markdownLinkDestination parses the inside of an inline link starting at start (just past the "("). It returns the
+// bounds of the destination and the index just past the closing ")". end is -1 if the link is not closed.
+func markdownLinkDestination(s string, start int) (destStart, destEnd, end int) {
+	i := start
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+		i++
+	}
+	destStart = i
+
+	if i < len(s) && s[i] == '<' {
+		for i < len(s) && s[i] != '>' && s[i] != '\n' {
+			i++
+		}
+		if i < len(s) && s[i] == '>' {
+			i++
+		}
+	} else {
+		depth := 0
+		for i < len(s) && s[i] > ' ' {
+			if s[i] == '\\' {
+				i += 2
+				continue
+			}
+			if s[i] == '(' {
+				depth++
+			} else if s[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+			i++
+		}
+	}
+	if i > len(s) {
+		i = len(s)
+	}
+	destEnd = i
+
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return destStart, destEnd, i + 1
+			}
+			depth--
+		}
+		i++
+	}
+
+	return destStart, destEnd, -1
+}
+
+// unsafeMarkdownURL reports whether dest uses an unsafe scheme after decoding entities and backslash escapes and
+// removing the whitespace and control characters browsers ignore.
+func unsafeMarkdownURL(dest string) bool {
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	dest = html.UnescapeString(dest)
+
+	sb := &strings.Builder{}
+	for _, r := range dest {
+		if r == '\\' || unicode.IsSpace(r) || unicode.IsControl(r) {
+			continue
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	normalized := sb.String()
+
+	for _, scheme := range markdownUnsafeSchemes {
+		if strings.HasPrefix(normalized, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeMarkdown(t *testing.T) {
+	tests := []struct {
+		value    any
+		stripped any
+		safe     bool
+	}{
+		{"Hello **world**", "Hello **world**", true},
+		{"a < b and [link](https://example.com)", "a < b and [link](https://example.com)", true},
+		{"Hi <b>there</b>", "Hi there", false},
+		{"<div class=\"x\">\nhello\n</div>", "\nhello\n", false},
+		{"Hi <!-- comment -->there", "Hi there", false},
+		{"[x](javascript:alert(1)) after", "[x](#) after", false},
+		{"[x](JaVaScRiPt&#58;alert(1))", "[x](#)", false},
+		{"![img](data:image/png;base64,AAAA \"title\")", "![img](# \"title\")", false},
+		{"<javascript:alert(1)> and <https://example.com>", " and <https://example.com>", false},
+		{"<foo@example.com>", "<foo@example.com>", true},
+		{"[id]: javascript:alert(1)", "[id]: #", false},
+		{"`<b>` and ``[x](javascript:y)``", "`<b>` and ``[x](javascript:y)``", true},
+		{"```\n<script>alert(1)</script>\n```\n<i>x</i>", "```\n<script>alert(1)</script>\n```\nx", false},
+		{"<img src=\"<\" onerror=alert(1)>", "", false},
+		{"<a title='>' href=\"javascript:alert(1)\">x</a>", "x", false},
+		{"<scr<script>ipt>alert(1)</script>", "&lt;script>alert(1)", false},
+		{"<img src=x onerror=alert(1)", "&lt;img src=x onerror=alert(1)", false},
+		{"a<b", "a&lt;b", false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.SafeMarkdown(ensure.MarkdownStrip).Ensure(tt.value)
+		assert.Equalf(t, tt.stripped, value, "%d", i)
+		assert.NoErrorf(t, err, "%d", i)
+
+		value, err = ensure.SafeMarkdown(ensure.MarkdownReject).Ensure(tt.value)
+		assert.Equalf(t, tt.safe, err == nil, "%d", i)
+		if tt.safe {
+			assert.Equalf(t, tt.value, value, "%d", i)
+		} else {
+			assert.Nilf(t, value, "%d", i)
+		}
+	}
+
+	_, err := ensure.SafeMarkdown(ensure.MarkdownStrip).Ensure(42)
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package ensure
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Mask returns a Ensurer that replaces every rune of value except the last keepLast with maskRune. e.g. Mask(4, '*')
+// turns "4111111111111111" into "************1111". It is intended to follow the ensurers that validate a field so
+// records bound for logs and analytics are sanitized in the same pass. If value has keepLast or fewer runes then all
+// of it is masked so short values are never stored in the clear. keepLast must not be negative or Mask panics. If value
+// is nil then nil is returned. If value is not a string then an error is returned.
+func Mask(keepLast int, maskRune rune) Ensurer {
+	if keepLast < 0 {
+		panic(fmt.Errorf("keepLast must not be negative: %d", keepLast))
+	}
+
+	ensurer := transformString(func(s string) string {
+		n := utf8.RuneCountInString(s)
+		if n <= keepLast {
+			return strings.Repeat(string(maskRune), n)
+		}
+
+		sb := &strings.Builder{}
+		sb.Grow(len(s))
+		i := 0
+		for _, r := range s {
+			if i < n-keepLast {
+				sb.WriteRune(maskRune)
+			} else {
+				sb.WriteRune(r)
+			}
+			i++
+		}
+		return sb.String()
+	})
+
+	return describe("mask", map[string]any{"keep_last": keepLast, "mask_rune": string(maskRune)}, ensurer)
+}
@@ -0,0 +1,34 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Mask(4, '*'), "4111111111111111", "************1111", true},
+		{ensure.Mask(4, '•'), "秘密のコード1234", "••••••1234", true},
+		{ensure.Mask(0, 'x'), "secret", "xxxxxx", true},
+		{ensure.Mask(4, '*'), "1234", "****", true},
+		{ensure.Mask(4, '*'), "12", "**", true},
+		{ensure.Mask(4, '*'), "", "", true},
+		{ensure.Mask(4, '*'), 4111, nil, false},
+		{ensure.Mask(4, '*'), nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	assert.Panics(t, func() { ensure.Mask(-1, '*') })
+}
@@ -0,0 +1,80 @@
+package ensure
+
+import (
+	"errors"
+	"regexp"
+)
+
+// MatchEnsurer is a Ensurer that checks value matches a regular expression. It is returned by Match and MatchPattern.
+type MatchEnsurer struct {
+	re          *regexp.Regexp
+	maxInputLen int
+}
+
+// Match returns a Ensurer that returns an error unless value matches re. Only a precompiled *regexp.Regexp is accepted
+// so matching always has RE2's linear time guarantee. re must not be nil or Match panics. If value is nil then nil is
+// returned. If value is not a string then an error is returned.
+func Match(re *regexp.Regexp) *MatchEnsurer {
+	if re == nil {
+		panic(errors.New("re must not be nil"))
+	}
+
+	return &MatchEnsurer{re: re}
+}
+
+// MatchPattern compiles pattern and returns a Ensurer like Match. It is intended for patterns that are not written by
+// the programmer, such as rules loaded from configuration. Each of vetters is called with the compiled expression and
+// may reject it, e.g. because the pattern is too long or has too many capture groups. An error is returned if pattern
+// does not compile or a vetter rejects it.
+func MatchPattern(pattern string, vetters ...func(*regexp.Regexp) error) (*MatchEnsurer, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vet := range vetters {
+		err := vet(re)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return Match(re), nil
+}
+
+// MaxInputLen returns a copy of me that fails without matching if value is longer than n bytes. This bounds the time
+// spent matching each value.
+func (me *MatchEnsurer) MaxInputLen(n int) *MatchEnsurer {
+	newME := *me
+	newME.maxInputLen = n
+	return &newME
+}
+
+func (me *MatchEnsurer) Describe() Rule {
+	params := map[string]any{"pattern": me.re.String()}
+	if me.maxInputLen > 0 {
+		params["max_bytes"] = me.maxInputLen
+	}
+	return Rule{Kind: "match", Params: params}
+}
+
+func (me *MatchEnsurer) Ensure(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, NewError("not_a_string", "not a string", nil)
+	}
+
+	if me.maxInputLen > 0 && len(s) > me.maxInputLen {
+		return nil, NewError("too_long", "too long", map[string]any{"max_bytes": me.maxInputLen})
+	}
+
+	if !me.re.MatchString(s) {
+		return nil, NewError("no_match", "does not match the required format", map[string]any{"pattern": me.re.String()})
+	}
+
+	return value, nil
+}
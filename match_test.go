@@ -0,0 +1,64 @@
+package ensure_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	sku := regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+
+	tests := []struct {
+		ensurer  ensure.Ensurer
+		value    any
+		expected any
+		success  bool
+	}{
+		{ensure.Match(sku), "ABC-1234", "ABC-1234", true},
+		{ensure.Match(sku), "abc-1234", nil, false},
+		{ensure.Match(sku), 1234, nil, false},
+		{ensure.Match(sku), nil, nil, true},
+		{ensure.Match(sku).MaxInputLen(8), "ABC-1234", "ABC-1234", true},
+		{ensure.Match(sku).MaxInputLen(7), "ABC-1234", nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+
+	_, err := ensure.Match(sku).Ensure("x")
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, err, &ensureErr)
+	assert.Equal(t, "no_match", ensureErr.Code)
+	assert.Equal(t, map[string]any{"pattern": `^[A-Z]{3}-\d{4}$`}, ensureErr.Params)
+
+	assert.Panics(t, func() { ensure.Match(nil) })
+}
+
+func TestMatchPattern(t *testing.T) {
+	maxGroups := func(re *regexp.Regexp) error {
+		if re.NumSubexp() > 1 {
+			return errors.New("too many groups")
+		}
+		return nil
+	}
+
+	me, err := ensure.MatchPattern(`^(\d+)$`, maxGroups)
+	require.NoError(t, err)
+	value, err := me.Ensure("42")
+	require.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	_, err = ensure.MatchPattern(`^(\d+)-(\d+)$`, maxGroups)
+	assert.EqualError(t, err, "too many groups")
+
+	_, err = ensure.MatchPattern(`(a+)+\1`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package ensure
+
+import (
+	"io"
+	"time"
+)
+
+// MediaInfo is metadata about an audio or video file.
+type MediaInfo struct {
+	Duration time.Duration
+	Bitrate  int64 // bits per second
+}
+
+// MediaProber reads metadata from an audio or video file of size bytes. Implementations typically wrap a media library
+// or a tool such as ffprobe.
+type MediaProber interface {
+	ProbeMedia(r io.ReaderAt, size int64) (MediaInfo, error)
+}
+
+func probeMedia(prober MediaProber, value any) (MediaInfo, error) {
+	r, closeUpload, err := openUpload(value)
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	defer closeUpload()
+
+	info, err := prober.ProbeMedia(r, r.Size())
+	if err != nil {
+		return MediaInfo{}, NewError("invalid_media", "not a valid media file", nil)
+	}
+
+	return info, nil
+}
+
+// MediaMaxDuration returns a Ensurer that uses prober to validate that value is a media file no longer than max. value
+// must be a []byte, *multipart.FileHeader, or a value such as *os.File that implements io.ReaderAt and io.Seeker. value
+// is returned unmodified. If value is nil then nil is returned.
+func MediaMaxDuration(prober MediaProber, max time.Duration) Ensurer {
+	return describe("mediamaxduration", map[string]any{"max": max}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		info, err := probeMedia(prober, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Duration > max {
+			return nil, NewError("media_too_long", "media too long", map[string]any{"max": max})
+		}
+
+		return value, nil
+	}))
+}
+
+// MediaMaxBitrate returns a Ensurer that uses prober to validate that value is a media file with a bitrate no greater
+// than max bits per second. value must be a []byte, *multipart.FileHeader, or a value such as *os.File that implements
+// io.ReaderAt and io.Seeker. value is returned unmodified. If value is nil then nil is returned.
+func MediaMaxBitrate(prober MediaProber, max int64) Ensurer {
+	return describe("mediamaxbitrate", map[string]any{"max": max}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		info, err := probeMedia(prober, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Bitrate > max {
+			return nil, NewError("media_bitrate_too_high", "media bitrate too high", map[string]any{"max": max})
+		}
+
+		return value, nil
+	}))
+}
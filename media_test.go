@@ -0,0 +1,53 @@
+package ensure_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProber reads a media file whose size in bytes is its duration in seconds at 1000 bits per second.
+type fakeProber struct{}
+
+func (fakeProber) ProbeMedia(r io.ReaderAt, size int64) (ensure.MediaInfo, error) {
+	buf := make([]byte, 4)
+	_, err := r.ReadAt(buf, 0)
+	if err != nil || string(buf) != "RIFF" {
+		return ensure.MediaInfo{}, errors.New("unknown format")
+	}
+
+	return ensure.MediaInfo{Duration: time.Duration(size) * time.Second, Bitrate: 1000}, nil
+}
+
+func TestMediaMaxDuration(t *testing.T) {
+	tests := []struct {
+		value   any
+		success bool
+	}{
+		{[]byte("RIFF"), true},
+		{[]byte("RIFF-12345"), false},
+		{[]byte("OggS"), false},
+		{"RIFF", false},
+		{nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.MediaMaxDuration(fakeProber{}, 5*time.Second).Ensure(tt.value)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+		}
+	}
+}
+
+func TestMediaMaxBitrate(t *testing.T) {
+	_, err := ensure.MediaMaxBitrate(fakeProber{}, 1000).Ensure([]byte("RIFF"))
+	assert.NoError(t, err)
+
+	_, err = ensure.MediaMaxBitrate(fakeProber{}, 999).Ensure([]byte("RIFF"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package ensure
+
+import (
+	"mime"
+	"strings"
+)
+
+// MIMEType returns a Ensurer that converts value to a normalized MIME type such as "text/html; charset=utf-8". The type
+// and parameter names are lowercased. If allowed is given then the media type must match one of allowed. An allowed
+// entry may end in "/*" to allow any subtype. If value is nil or a blank string nil is returned. If value is not a
+// string then an error is returned.
+func MIMEType(allowed ...string) Ensurer {
+	normalizedAllowed := make([]string, len(allowed))
+	for i, a := range allowed {
+		normalizedAllowed[i] = strings.ToLower(a)
+	}
+
+	return describe("mimetype", map[string]any{"allowed": allowed}, EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		mediaType, params, err := mime.ParseMediaType(s)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, NewError("invalid_mime_type", "not a valid MIME type", nil)
+		}
+
+		if len(normalizedAllowed) > 0 && !mimeTypeAllowed(mediaType, normalizedAllowed) {
+			return nil, NewError("not_allowed", "not allowed value", map[string]any{"allowed": allowed})
+		}
+
+		return mime.FormatMediaType(mediaType, params), nil
+	}))
+}
+
+func mimeTypeAllowed(mediaType string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "*"); ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMIMEType(t *testing.T) {
+	tests := []struct {
+		value    any
+		allowed  []string
+		expected any
+		success  bool
+	}{
+		{"application/json", nil, "application/json", true},
+		{" Text/HTML; Charset=UTF-8 ", nil, "text/html; charset=UTF-8", true},
+		{"image/png", []string{"image/*"}, "image/png", true},
+		{"application/json", []string{"image/*", "application/json"}, "application/json", true},
+		{"text/plain", []string{"image/*"}, nil, false},
+		{"json", nil, nil, false},
+		{"text/html; charset", nil, nil, false},
+		{42, nil, nil, false},
+		{nil, nil, nil, true},
+		{"", nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.MIMEType(tt.allowed...).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
@@ -0,0 +1,28 @@
+package ensure
+
+// Overlay returns a copy of re that also ensures the fields of extra, such as the rules of a tenant compiled from a
+// schema.Schema, at the end of the last phase of re. Fields of extra see the values converted by re, so extra usually
+// only has constraints such as a stricter MaxLen or a Require for another field. Unlike Then, the errors of extra are
+// reported with those of the last phase of re. A field that already has an error is not ensured again by extra so an
+// error is not reported twice. Any later phases of extra are added as later phases. Overlay only copies re, so extra
+// can be compiled once and overlaid at request time. e.g.
+//
+//	re := PersonEnsurer.Overlay(tenantEnsurers[tenantID])
+func (re *RecordEnsurer) Overlay(extra *RecordEnsurer) *RecordEnsurer {
+	newRE := *re
+	newRE.phases = make([]EnsureRecordFunc, 0, len(re.phases)+len(extra.phases)-1)
+	newRE.phases = append(newRE.phases, re.phases[:len(re.phases)-1]...)
+
+	last, first := re.phases[len(re.phases)-1], extra.phases[0]
+	newRE.phases = append(newRE.phases, func(r *RecordWithErrors) {
+		last(r)
+		r.wait()
+
+		r.overlay = true
+		defer func() { r.overlay = false }()
+		first(r)
+	})
+
+	newRE.phases = append(newRE.phases, extra.phases[1:]...)
+	return &newRE
+}
@@ -0,0 +1,76 @@
+package ensure_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEnsurerOverlay(t *testing.T) {
+	base := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.SingleLineString(), ensure.Require(), ensure.MaxLen(100))
+		r.Ensure("age", ensure.Int32())
+	})
+	tenant := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.MaxLen(10))
+		r.Ensure("age", ensure.GreaterThanOrEqual(18))
+		r.Ensure("tax_id", ensure.String(), ensure.Require())
+	})
+	re := base.Overlay(tenant)
+
+	tests := []struct {
+		record map[string]any
+		errors map[string]int
+	}{
+		{map[string]any{"name": " Jack ", "age": "42", "tax_id": "123"}, nil},
+		{map[string]any{"name": "Jack", "age": "42"}, map[string]int{"tax_id": 1}},
+		{map[string]any{"name": "Jack Christensen", "age": "17", "tax_id": "123"}, map[string]int{"name": 1, "age": 1}},
+		{
+			map[string]any{"name": strings.Repeat("x", 101), "age": "abc"},
+			map[string]int{"name": 1, "age": 1, "tax_id": 1},
+		},
+	}
+
+	for i, tt := range tests {
+		_, err := re.Ensure(tt.record)
+		if tt.errors == nil {
+			assert.NoErrorf(t, err, "%d", i)
+			continue
+		}
+
+		var recordErrs *ensure.RecordErrors
+		require.ErrorAsf(t, err, &recordErrs, "%d", i)
+		byField := recordErrs.ByField()
+		assert.Lenf(t, byField, len(tt.errors), "%d", i)
+		for field, n := range tt.errors {
+			assert.Lenf(t, byField[field], n, "%d: %s", i, field)
+		}
+	}
+
+	record := map[string]any{"name": " Jack ", "age": "42", "tax_id": 123}
+	_, err := re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Jack", "age": int32(42), "tax_id": "123"}, record)
+
+	_, err = base.Ensure(map[string]any{"name": "Jack Christensen"})
+	assert.NoError(t, err)
+
+	fields := re.Rules()
+	require.Len(t, fields, 3)
+	assert.Equal(t, "tax_id", fields[2].Field)
+	assert.Len(t, fields[0].Rules, 4)
+
+	later := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {}).Then(func(r *ensure.RecordWithErrors) {
+		r.Add("name", ensure.NewError("taken", "is already taken", nil))
+	})
+	_, err = base.Overlay(later).Ensure(map[string]any{"name": "Jack", "age": "abc"})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Equal(t, []string{"age"}, recordErrs.Fields())
+	_, err = base.Overlay(later).Ensure(map[string]any{"name": "Jack"})
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Equal(t, []string{"name"}, recordErrs.Fields())
+}
@@ -0,0 +1,97 @@
+package ensure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nanpRegions are the regions that use the North American Numbering Plan (country calling code 1).
+var nanpRegions = map[string]struct{}{
+	"US": {}, "CA": {}, "AG": {}, "AI": {}, "AS": {}, "BB": {}, "BM": {}, "BS": {}, "DM": {}, "DO": {}, "GD": {},
+	"GU": {}, "JM": {}, "KN": {}, "KY": {}, "LC": {}, "MP": {}, "MS": {}, "PR": {}, "SX": {}, "TC": {}, "TT": {},
+	"VC": {}, "VG": {}, "VI": {},
+}
+
+// PhoneNumber returns a Ensurer that normalizes a phone number to E.164 format (e.g. "+12025550123"). Punctuation
+// commonly used in phone numbers ("(", ")", "-", ".", and space) is removed.
+//
+// Numbers in international format (a leading "+" or "00") are accepted for any country. Numbers without a country code
+// are interpreted according to defaultRegion, which must be an ISO 3166-1 alpha-2 code of a North American Numbering
+// Plan region such as "US" or "CA", or "" to require international format. PhoneNumber panics if defaultRegion is not
+// supported. Numbers with country code 1 must be valid NANP numbers.
+//
+// If value is nil or a blank string nil is returned. If value is not a string then an error is returned.
+func PhoneNumber(defaultRegion string) Ensurer {
+	defaultRegion = strings.ToUpper(defaultRegion)
+	if defaultRegion != "" {
+		if _, ok := nanpRegions[defaultRegion]; !ok {
+			panic(fmt.Errorf("unsupported phone number region: %s", defaultRegion))
+		}
+	}
+
+	ensurer := EnsurerFunc(func(value any) (any, error) {
+		value = normalizeForParsing(value)
+
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		international := false
+		if strings.HasPrefix(s, "+") {
+			international = true
+			s = s[1:]
+		}
+
+		digits := make([]byte, 0, len(s))
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			switch {
+			case c >= '0' && c <= '9':
+				digits = append(digits, c)
+			case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+			default:
+				return nil, NewError("invalid_phone_number", "not a valid phone number", nil)
+			}
+		}
+
+		if !international && len(digits) > 2 && digits[0] == '0' && digits[1] == '0' {
+			international = true
+			digits = digits[2:]
+		}
+
+		if !international {
+			if defaultRegion == "" {
+				return nil, NewError("invalid_phone_number", "not a valid phone number", nil)
+			}
+			if len(digits) == 10 {
+				digits = append([]byte{'1'}, digits...)
+			}
+			if len(digits) != 11 || digits[0] != '1' {
+				return nil, NewError("invalid_phone_number", "not a valid phone number", nil)
+			}
+		}
+
+		if len(digits) < 7 || len(digits) > 15 || digits[0] == '0' {
+			return nil, NewError("invalid_phone_number", "not a valid phone number", nil)
+		}
+
+		if digits[0] == '1' && !validNANPNumber(digits[1:]) {
+			return nil, NewError("invalid_phone_number", "not a valid phone number", nil)
+		}
+
+		return "+" + string(digits), nil
+	})
+
+	return describe("phonenumber", map[string]any{"default_region": defaultRegion}, ensurer)
+}
+
+// validNANPNumber reports whether digits is a plausible 10 digit North American number. Both the area code and the
+// exchange code must begin with 2-9.
+func validNANPNumber(digits []byte) bool {
+	return len(digits) == 10 && digits[0] >= '2' && digits[3] >= '2'
+}
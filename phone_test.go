@@ -0,0 +1,44 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneNumber(t *testing.T) {
+	tests := []struct {
+		value    any
+		region   string
+		expected any
+		success  bool
+	}{
+		{"(202) 555-0123", "US", "+12025550123", true},
+		{"202.555.0123", "US", "+12025550123", true},
+		{"1-202-555-0123", "US", "+12025550123", true},
+		{" +1 416 555 0199 ", "CA", "+14165550199", true},
+		{"+44 20 7946 0958", "US", "+442079460958", true},
+		{"0044 20 7946 0958", "US", "+442079460958", true},
+		{"+44 20 7946 0958", "", "+442079460958", true},
+		{"202-555-0123", "", nil, false},
+		{"(102) 555-0123", "US", nil, false},
+		{"202-155-0123", "US", nil, false},
+		{"555-0123", "US", nil, false},
+		{"+1 202 555 012", "US", nil, false},
+		{"+0 123 456 789", "US", nil, false},
+		{"+1234567890123456", "US", nil, false},
+		{"202-555-CALL", "US", nil, false},
+		{42, "US", nil, false},
+		{nil, "US", nil, true},
+		{" ", "US", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.PhoneNumber(tt.region).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+
+	assert.Panics(t, func() { ensure.PhoneNumber("XX") })
+}
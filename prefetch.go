@@ -0,0 +1,105 @@
+package ensure
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// PrefetchFunc looks up values in one batch such as with a single SQL query using "= ANY($1)". It returns the results
+// keyed by value. Values that are not found should be left out.
+type PrefetchFunc func(ctx context.Context, values []any) (map[any]any, error)
+
+// Prefetch returns a Ensurer for a slice of records that makes one lookup for all of them instead of one per record. It
+// converts field of each record with convert, which should be the converter the records use for field such as
+// Int64(), and calls fn once with the distinct values that converted to a comparable value other than nil. Then value
+// is ensured with ensurer, usually Slice of Nested, with the results available to Prefetched and PrefetchedValue.
+// e.g.
+//
+//	r.Ensure("items", ensure.Prefetch("product_id", ensure.Int64(), findProducts, ensure.Slice[map[string]any](
+//		ensure.Nested(func(r *ensure.RecordWithErrors) {
+//			r.Ensure("product_id", ensure.Int64(), ensure.Require(), ensure.Prefetched("product_id"))
+//		}),
+//	)))
+//
+// Elements that are not a map[string]any or GetterSetter are skipped. If fn returns an error it is returned and ensurer
+// is not called. If value is nil or there are no values then fn is not called.
+func Prefetch(field string, convert Ensurer, fn PrefetchFunc, ensurer Ensurer) Ensurer {
+	prefetch := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		var values []any
+		seen := make(map[any]struct{})
+
+		if elements, ok := value.([]any); ok {
+			for _, element := range elements {
+				var record GetterSetter
+				switch element := element.(type) {
+				case GetterSetter:
+					record = element
+				case map[string]any:
+					record = GetterSetterMap(element)
+				default:
+					continue
+				}
+
+				v, err := ensureContext(ctx, convert, record.Get(field))
+				if err != nil || v == nil || !reflect.TypeOf(v).Comparable() {
+					continue
+				}
+				if _, ok := seen[v]; !ok {
+					seen[v] = struct{}{}
+					values = append(values, v)
+				}
+			}
+		}
+
+		results := map[any]any{}
+		if len(values) > 0 {
+			var err error
+			results, err = fn(ctx, values)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ensureContext(context.WithValue(ctx, prefetchKey{field: field}, results), ensurer, value)
+	})
+
+	return describe("prefetch", map[string]any{"field": field, "ensurers": []Ensurer{ensurer}}, prefetch)
+}
+
+type prefetchKey struct {
+	field string
+}
+
+// PrefetchedValue returns the result of the Prefetch of field for value. ok is false if value was not found or ctx is
+// not from a Prefetch of field. It is intended for ensurers that need more than whether value exists.
+func PrefetchedValue(ctx context.Context, field string, value any) (result any, ok bool) {
+	results, ok := ctx.Value(prefetchKey{field: field}).(map[any]any)
+	if !ok || value == nil || !reflect.TypeOf(value).Comparable() {
+		return nil, false
+	}
+	result, ok = results[value]
+	return result, ok
+}
+
+// Prefetched returns a Ensurer that fails with the code "not_found" unless value was found by the Prefetch of field.
+// It must be used inside the ensurer given to Prefetch. If value is nil then nil is returned.
+func Prefetched(field string) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		if _, ok := ctx.Value(prefetchKey{field: field}).(map[any]any); !ok {
+			return nil, fmt.Errorf("no Prefetch of %q", field)
+		}
+
+		if _, ok := PrefetchedValue(ctx, field, value); !ok {
+			return nil, NewError("not_found", "does not exist", nil)
+		}
+
+		return value, nil
+	})
+
+	return describe("prefetched", map[string]any{"field": field}, ensurer)
+}
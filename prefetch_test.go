@@ -0,0 +1,88 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefetch(t *testing.T) {
+	products := map[int64]string{1: "Widget", 2: "Gadget"}
+
+	var lookups [][]any
+	findProducts := func(ctx context.Context, values []any) (map[any]any, error) {
+		lookups = append(lookups, values)
+		results := make(map[any]any)
+		for _, v := range values {
+			if name, ok := products[v.(int64)]; ok {
+				results[v] = name
+			}
+		}
+		return results, nil
+	}
+
+	productName := ensure.EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		name, _ := ensure.PrefetchedValue(ctx, "product_id", value)
+		return name, nil
+	})
+
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("items", ensure.Prefetch("product_id", ensure.Int64(), findProducts, ensure.Slice[map[string]any](
+			ensure.Nested(func(r *ensure.RecordWithErrors) {
+				r.Ensure("product_id", ensure.Int64(), ensure.Require(), ensure.Prefetched("product_id"))
+				r.EnsureAs("product_id", "name", productName)
+			}),
+		)))
+	})
+
+	record := map[string]any{"items": []any{
+		map[string]any{"product_id": "1"},
+		map[string]any{"product_id": 2},
+		map[string]any{"product_id": "1"},
+	}}
+	_, err := re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{int64(1), int64(2)}}, lookups)
+	assert.Equal(t, []map[string]any{
+		{"product_id": int64(1), "name": "Widget"},
+		{"product_id": int64(2), "name": "Gadget"},
+		{"product_id": int64(1), "name": "Widget"},
+	}, record["items"])
+
+	lookups = nil
+	record = map[string]any{"items": []any{
+		map[string]any{"product_id": "99"},
+		map[string]any{"product_id": "abc"},
+		map[string]any{"product_id": nil},
+		"bogus",
+	}}
+	_, err = re.Ensure(record)
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	byField := recordErrs.ByField()
+	assert.Equal(t, []any{int64(99)}, lookups[0])
+	require.Len(t, byField["items[0].product_id"], 1)
+	assert.ErrorIs(t, byField["items[0].product_id"][0].Err, ensure.NewError("not_found", "", nil))
+	assert.Len(t, byField["items[1].product_id"], 1)
+	assert.Len(t, byField["items[2].product_id"], 1)
+	assert.Len(t, byField["items[3]"], 1)
+
+	lookups = nil
+	_, err = re.Ensure(map[string]any{"items": nil})
+	assert.NoError(t, err)
+	assert.Empty(t, lookups)
+
+	errDown := errors.New("database down")
+	failing := ensure.Prefetch("id", ensure.Int64(), func(context.Context, []any) (map[any]any, error) {
+		return nil, errDown
+	}, ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {})))
+	_, err = failing.Ensure([]any{map[string]any{"id": 1}})
+	assert.ErrorIs(t, err, errDown)
+
+	_, err = ensure.Prefetched("id").Ensure(int64(1))
+	assert.Error(t, err)
+}
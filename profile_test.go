@@ -0,0 +1,33 @@
+package ensure_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.String())
+		r.Ensure("slow", ensure.EnsurerFunc(func(value any) (any, error) {
+			time.Sleep(time.Millisecond)
+			return strings.Repeat("x", 1024), nil
+		}))
+	})
+	sample := map[string]any{"name": "jack", "slow": "x"}
+
+	profiles := ensure.Profile(re, sample, 3)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "slow", profiles[0].Field)
+	assert.Equal(t, 3, profiles[0].Calls)
+	assert.GreaterOrEqual(t, profiles[0].Duration, 3*time.Millisecond)
+	assert.GreaterOrEqual(t, profiles[0].Bytes, uint64(3*1024))
+	assert.Equal(t, "name", profiles[1].Field)
+	assert.Equal(t, 3, profiles[1].Calls)
+
+	assert.Equal(t, map[string]any{"name": "jack", "slow": "x"}, sample)
+}
@@ -0,0 +1,54 @@
+package ensure
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Quarantine writes the records of a batch that fail to an io.Writer as lines of JSON so they can be reviewed, fixed,
+// and imported again. Each line has the "index" of the record in the batch, an "input" object holding its original
+// values, and an "errors" array with the "field", "label", "code", and "message" of each error. Values that cannot be
+// marshaled to JSON are written as strings. It is safe for concurrent use.
+type Quarantine struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewQuarantine returns a Quarantine that writes to w.
+func NewQuarantine(w io.Writer) *Quarantine {
+	return &Quarantine{w: w}
+}
+
+// Write writes record to the quarantine if err is not nil. err is the error returned by Record or RecordEnsurer.Ensure
+// for record. Ensuring modifies record so a copy of its values made before ensuring should be passed. An error that is
+// not a *RecordErrors is written with an empty field. Any error writing to the underlying io.Writer is returned.
+func (q *Quarantine) Write(index int, record map[string]any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors []*FieldError
+	var recordErrs *RecordErrors
+	if errors.As(err, &recordErrs) {
+		fieldErrors = recordErrs.FieldErrors()
+	} else {
+		fieldErrors = []*FieldError{{Err: err}}
+	}
+
+	failure := capturedFailure{
+		Index:  &index,
+		Input:  copyValue(map[string]any(record)).(map[string]any),
+		Errors: captureErrors(fieldErrors),
+	}
+
+	buf, err := failure.marshal()
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, err = q.w.Write(buf)
+	return err
+}
@@ -0,0 +1,51 @@
+package ensure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantine(t *testing.T) {
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("age", ensure.Int32())
+	})
+
+	rows := []map[string]any{
+		{"name": "Jack", "age": "42"},
+		{"name": "", "age": "abc"},
+	}
+
+	buf := &bytes.Buffer{}
+	q := ensure.NewQuarantine(buf)
+	for i, row := range rows {
+		original := map[string]any{"name": row["name"], "age": row["age"]}
+		_, err := re.Ensure(row)
+		require.NoError(t, q.Write(i, original, err))
+	}
+	require.NoError(t, q.Write(2, map[string]any{"line": "a,b"}, errors.New("wrong number of fields")))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var failure map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &failure))
+	assert.Equal(t, map[string]any{
+		"index": 1.0,
+		"input": map[string]any{"name": "", "age": "abc"},
+		"errors": []any{
+			map[string]any{"field": "name", "code": "required", "message": "cannot be nil or empty"},
+			map[string]any{"field": "age", "code": "not_a_number", "message": "not a valid number"},
+		},
+	}, failure)
+
+	require.NoError(t, json.Unmarshal(lines[1], &failure))
+	assert.Equal(t, 2.0, failure["index"])
+	assert.Equal(t, []any{map[string]any{"field": "", "message": "wrong number of fields"}}, failure["errors"])
+}
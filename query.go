@@ -0,0 +1,137 @@
+package ensure
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query ensures the query parameters in values with fn and returns the resulting fields as a map. Every key in values
+// is present in the map fn sees. A key with a single value is a string, an empty string if the key was given without
+// a value, and a key with multiple values is a []any of strings. Keys that are not in values are nil, so ensurers such
+// as Flag can distinguish presence from absence. If any field fails the returned error is a *RecordErrors and the map
+// is nil.
+func Query(values url.Values, fn EnsureRecordFunc) (map[string]any, error) {
+	record := make(GetterSetterMap, len(values))
+	setValues(record, values)
+
+	err := Record(record, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// setValues sets each key of values in record following the rules of Query.
+func setValues(record GetterSetterMap, values url.Values) {
+	for key, vals := range values {
+		switch len(vals) {
+		case 0:
+			record[key] = ""
+		case 1:
+			record[key] = vals[0]
+		default:
+			anys := make([]any, len(vals))
+			for i, v := range vals {
+				anys[i] = v
+			}
+			record[key] = anys
+		}
+	}
+}
+
+// Flag returns a Ensurer that converts value to a bool using the presence rules of query strings and HTML forms. nil,
+// meaning the key was absent, is false. An empty string, meaning the key was given without a value, is true. Otherwise
+// the value is parsed like Bool with "on", "off", "yes", and "no" also accepted. If value is a []any, such as from a
+// hidden field followed by a checkbox of the same name, the last element is used.
+func Flag() Ensurer {
+	return describe("flag", nil, EnsurerFunc(func(value any) (any, error) {
+		if values, ok := value.([]any); ok {
+			if len(values) == 0 {
+				return false, nil
+			}
+			value = values[len(values)-1]
+		}
+
+		switch value := value.(type) {
+		case nil:
+			return false, nil
+		case bool:
+			return value, nil
+		case string:
+			s := strings.ToLower(strings.TrimSpace(value))
+			switch s {
+			case "", "on", "yes":
+				return true, nil
+			case "off", "no":
+				return false, nil
+			}
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, NewError("not_a_boolean", "not a valid boolean", nil)
+			}
+			return b, nil
+		default:
+			return nil, NewError("not_a_boolean", "not a valid boolean", nil)
+		}
+	}))
+}
+
+// Values adapts url.Values to a GetterSetter so query parameters and form values can be ensured with the same record
+// definitions as JSON bodies. The values of a multipart.Form can be adapted with Values(form.Value). Get returns nil if
+// the key is not present, a string if it has one value or no values, and a []any of strings if it has multiple values.
+// Set stores value as the key's only value, a []string or []any as multiple values, and deletes the key if value is
+// nil. Values that are not strings are converted with fmt.Sprint. Use Query instead to keep the converted values.
+type Values url.Values
+
+func (v Values) Get(key string) any {
+	vals, ok := v[key]
+	if !ok {
+		return nil
+	}
+
+	switch len(vals) {
+	case 0:
+		return ""
+	case 1:
+		return vals[0]
+	}
+
+	anys := make([]any, len(vals))
+	for i, s := range vals {
+		anys[i] = s
+	}
+	return anys
+}
+
+func (v Values) Set(key string, value any) {
+	switch value := value.(type) {
+	case nil:
+		delete(v, key)
+	case []string:
+		v[key] = append([]string(nil), value...)
+	case []any:
+		vals := make([]string, len(value))
+		for i, s := range value {
+			vals[i] = convertString(s)
+		}
+		v[key] = vals
+	default:
+		v[key] = []string{convertString(value)}
+	}
+}
+
+// Keys returns the keys of v in no particular order.
+func (v Values) Keys() []string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Delete deletes key from v.
+func (v Values) Delete(key string) {
+	delete(v, key)
+}
@@ -0,0 +1,110 @@
+package ensure_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	values, err := url.ParseQuery("page=2&tag=a&tag=b&archived&q=")
+	require.NoError(t, err)
+
+	params, err := ensure.Query(values, func(r *ensure.RecordWithErrors) {
+		r.Ensure("page", ensure.Int32(), ensure.GreaterThanOrEqual(1))
+		r.Ensure("tag", ensure.Slice[string](ensure.String()))
+		r.Ensure("archived", ensure.Flag())
+		r.Ensure("deleted", ensure.Flag())
+		r.Ensure("q", ensure.String())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"page":     int32(2),
+		"tag":      []string{"a", "b"},
+		"archived": true,
+		"deleted":  false,
+		"q":        "",
+	}, params)
+
+	values, err = url.ParseQuery("page=0&archived=maybe")
+	require.NoError(t, err)
+
+	params, err = ensure.Query(values, func(r *ensure.RecordWithErrors) {
+		r.Ensure("page", ensure.Int32(), ensure.GreaterThanOrEqual(1))
+		r.Ensure("archived", ensure.Flag())
+	})
+	require.Error(t, err)
+	assert.Nil(t, params)
+
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"page"}), 1)
+	assert.Len(t, etErr.Get([]any{"archived"}), 1)
+}
+
+func TestFlag(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{nil, false, true},
+		{"", true, true},
+		{"on", true, true},
+		{"1", true, true},
+		{"TRUE", true, true},
+		{"off", false, true},
+		{"0", false, true},
+		{"no", false, true},
+		{true, true, true},
+		{[]any{"0", "1"}, true, true},
+		{[]any{"0"}, false, true},
+		{"maybe", nil, false},
+		{42, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.Flag().Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestValues(t *testing.T) {
+	urlValues, err := url.ParseQuery("page=2&tag=a&tag=b&archived&admin=true")
+	require.NoError(t, err)
+	urlValues["empty"] = []string{}
+
+	values := ensure.Values(urlValues)
+	assert.Equal(t, "2", values.Get("page"))
+	assert.Equal(t, []any{"a", "b"}, values.Get("tag"))
+	assert.Equal(t, "", values.Get("archived"))
+	assert.Equal(t, "", values.Get("empty"))
+	assert.Nil(t, values.Get("missing"))
+
+	err = ensure.Record(values, func(r *ensure.RecordWithErrors) {
+		r.Permit("page", "tag", "archived", "per_page")
+		r.Ensure("page", ensure.Int32(), ensure.GreaterThanOrEqual(1))
+		r.Ensure("per_page", ensure.Int32(), ensure.NilifyEmpty())
+		r.Ensure("tag", ensure.Slice[string](ensure.Upper()))
+		r.Ensure("archived", ensure.Flag())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"page":     {"2"},
+		"tag":      {"A", "B"},
+		"archived": {"true"},
+	}, urlValues)
+
+	values = ensure.Values(url.Values{"page": {"0"}})
+	err = ensure.Record(values, func(r *ensure.RecordWithErrors) {
+		r.Ensure("page", ensure.Int32(), ensure.GreaterThanOrEqual(1))
+	})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"page"}), 1)
+}
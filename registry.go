@@ -0,0 +1,285 @@
+package ensure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry maps rule names to constructors of ensurers so ensurers can be declared by strings such as struct tags and
+// configuration files. Applications can add their own named rules with Register. NewRegistry returns a Registry with
+// the built-in rules. It is safe for concurrent use.
+//
+// The built-in rules are require, notnil, nilifyempty, string, singleline, multiline, collapsespaces, lower, upper,
+// titlecase, bool, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64, decimal, bigint,
+// uuid, date, time, url, json, jsonobject, jsonarray, alpha, alphanumeric, numeric, ascii, and printableascii, which
+// take no arguments except that date and time take optional formats and url takes optional schemes, and minlen=n,
+// maxlen=n, min=x, max=x, gt=x, lt=x, between=x y, oneof=a b c, prefix=s, suffix=s, contains=s, and match=pattern
+// with an optional maximum input length in bytes. A pattern with spaces can only be given as a single argument such as
+// in the args of a rule of a schema.Schema.
+type Registry struct {
+	mu           sync.RWMutex
+	constructors map[string]func(args ...string) Ensurer
+}
+
+// DefaultRegistry is the Registry used by ForStruct.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a new Registry with the built-in rules.
+func NewRegistry() *Registry {
+	r := &Registry{constructors: make(map[string]func(args ...string) Ensurer)}
+
+	for name, fn := range map[string]func() Ensurer{
+		"require":        Require,
+		"notnil":         NotNil,
+		"nilifyempty":    NilifyEmpty,
+		"string":         String,
+		"singleline":     SingleLineString,
+		"multiline":      MultiLineString,
+		"collapsespaces": CollapseSpaces,
+		"lower":          Lower,
+		"upper":          Upper,
+		"titlecase":      TitleCase,
+		"bool":           Bool,
+		"int":            Int,
+		"int8":           Int8,
+		"int16":          Int16,
+		"int32":          Int32,
+		"int64":          Int64,
+		"uint8":          Uint8,
+		"uint16":         Uint16,
+		"uint32":         Uint32,
+		"uint64":         Uint64,
+		"float32":        Float32,
+		"float64":        Float64,
+		"decimal":        Decimal,
+		"bigint":         BigInt,
+		"uuid":           UUID,
+		"json":           func() Ensurer { return JSON() },
+		"jsonobject":     func() Ensurer { return JSONObject() },
+		"jsonarray":      func() Ensurer { return JSONArray() },
+		"alpha":          func() Ensurer { return Alpha() },
+		"alphanumeric":   func() Ensurer { return Alphanumeric() },
+		"numeric":        func() Ensurer { return Numeric() },
+		"ascii":          func() Ensurer { return ASCII() },
+		"printableascii": func() Ensurer { return PrintableASCII() },
+	} {
+		r.Register(name, noArgs(name, fn))
+	}
+
+	r.Register("date", func(args ...string) Ensurer { return Date(args...) })
+	r.Register("time", func(args ...string) Ensurer { return Time(args...) })
+	r.Register("url", func(args ...string) Ensurer { return URL(args...) })
+
+	r.Register("minlen", intArg("minlen", MinLen))
+	r.Register("maxlen", intArg("maxlen", MaxLen))
+
+	for name, fn := range map[string]func(x any) Ensurer{
+		"min": GreaterThanOrEqual,
+		"max": LessThanOrEqual,
+		"gt":  GreaterThan,
+		"lt":  LessThan,
+	} {
+		fn := fn
+		r.Register(name, oneArg(name, func(s string) Ensurer { return fn(s) }))
+	}
+
+	r.Register("between", func(args ...string) Ensurer {
+		if len(args) != 2 {
+			panic(fmt.Errorf("between requires 2 arguments: %d given", len(args)))
+		}
+		return Between(args[0], args[1])
+	})
+	r.Register("oneof", func(args ...string) Ensurer {
+		if len(args) == 0 {
+			panic(fmt.Errorf("oneof requires at least 1 argument"))
+		}
+		return OneOf(args...)
+	})
+
+	r.Register("prefix", oneArg("prefix", HasPrefix))
+	r.Register("suffix", oneArg("suffix", HasSuffix))
+	r.Register("contains", oneArg("contains", Contains))
+	r.Register("match", func(args ...string) Ensurer {
+		if len(args) != 1 && len(args) != 2 {
+			panic(fmt.Errorf("match requires 1 or 2 arguments: %d given", len(args)))
+		}
+		me, err := MatchPattern(args[0])
+		if err != nil {
+			panic(err)
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				panic(fmt.Errorf("match requires a positive integer max bytes: %s", args[1]))
+			}
+			me = me.MaxInputLen(n)
+		}
+		return me
+	})
+
+	return r
+}
+
+// Register adds the rule name to r. fn is called with the arguments of the rule each time it is used. It should panic
+// if the arguments are invalid, like the built-in constructors. A rule of the same name is replaced.
+func (r *Registry) Register(name string, fn func(args ...string) Ensurer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.constructors[name] = fn
+}
+
+// Lookup returns the ensurer for the rule name with args. An error is returned if name is not registered or args are
+// invalid.
+func (r *Registry) Lookup(name string, args ...string) (Ensurer, error) {
+	r.mu.RLock()
+	fn, ok := r.constructors[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown rule: %s", name)
+	}
+
+	return construct(fn, args)
+}
+
+// Parse returns the ensurers for rules. rules is a comma separated list of rule names. A rule name may be followed by
+// "=" and space separated arguments such as "maxlen=100" or "oneof=red green blue". e.g.
+//
+//	ensurers, err := ensure.DefaultRegistry.Parse("singleline,require,maxlen=100")
+//
+// An error is returned if any rule is unknown or has invalid arguments.
+func (r *Registry) Parse(rules string) ([]Ensurer, error) {
+	var ensurers []Ensurer
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, argStr, _ := strings.Cut(rule, "=")
+		e, err := r.Lookup(name, strings.Fields(argStr)...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rule, err)
+		}
+		ensurers = append(ensurers, e)
+	}
+
+	return ensurers, nil
+}
+
+// Names returns the names of the rules in r in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.constructors))
+	for name := range r.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// construct calls fn with args and returns a panic as an error.
+func construct(fn func(args ...string) Ensurer, args []string) (e Ensurer, err error) {
+	defer func() {
+		if pv := recover(); pv != nil {
+			if pvErr, ok := pv.(error); ok {
+				err = pvErr
+			} else {
+				err = fmt.Errorf("%v", pv)
+			}
+		}
+	}()
+
+	return fn(args...), nil
+}
+
+func noArgs(name string, fn func() Ensurer) func(args ...string) Ensurer {
+	return func(args ...string) Ensurer {
+		if len(args) != 0 {
+			panic(fmt.Errorf("%s does not take arguments", name))
+		}
+		return fn()
+	}
+}
+
+func oneArg(name string, fn func(string) Ensurer) func(args ...string) Ensurer {
+	return func(args ...string) Ensurer {
+		if len(args) != 1 {
+			panic(fmt.Errorf("%s requires 1 argument: %d given", name, len(args)))
+		}
+		return fn(args[0])
+	}
+}
+
+func intArg(name string, fn func(int) Ensurer) func(args ...string) Ensurer {
+	return oneArg(name, func(s string) Ensurer {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			panic(fmt.Errorf("%s requires an integer argument: %s", name, s))
+		}
+		return fn(n)
+	})
+}
+
+// ForStruct returns a RecordEnsurer built from the "ensure" tags of the fields of the struct T using DefaultRegistry.
+// Each tag is a comma separated list of rules that are applied in order. e.g.
+//
+//	type Person struct {
+//		Name string `json:"name" ensure:"singleline,require,maxlen=100"`
+//		Age  int32  `json:"age" ensure:"int32,min=0"`
+//	}
+//
+//	var PersonEnsurer = ensure.ForStruct[Person]()
+//
+// The record field name is taken from the "json" tag or else the Go name of the field, the same as
+// RecordEnsurer.EnsureInto. Fields without an "ensure" tag are not ensured. ForStruct panics if T is not a struct or a
+// tag is invalid.
+func ForStruct[T any]() *RecordEnsurer {
+	return ForStructRegistry[T](DefaultRegistry)
+}
+
+// ForStructRegistry is like ForStruct but uses registry so the tags can use application specific rules.
+func ForStructRegistry[T any](registry *Registry) *RecordEnsurer {
+	var zero T
+	st := reflect.TypeOf(zero)
+	if st == nil || st.Kind() != reflect.Struct {
+		panic(fmt.Errorf("%T is not a struct", zero))
+	}
+
+	type structField struct {
+		name     string
+		ensurers []Ensurer
+	}
+	var fields []structField
+
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		name, ok := structFieldName(sf)
+		if !ok {
+			continue
+		}
+
+		rules, ok := sf.Tag.Lookup("ensure")
+		if !ok {
+			continue
+		}
+
+		ensurers, err := registry.Parse(rules)
+		if err != nil {
+			panic(fmt.Errorf("%s.%s: %w", st.Name(), sf.Name, err))
+		}
+
+		fields = append(fields, structField{name: name, ensurers: ensurers})
+	}
+
+	return NewRecordEnsurer(func(r *RecordWithErrors) {
+		for _, f := range fields {
+			r.Ensure(f.name, f.ensurers...)
+		}
+	})
+}
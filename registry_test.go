@@ -0,0 +1,137 @@
+package ensure_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registryPerson struct {
+	Name    string  `json:"name" ensure:"singleline,nilifyempty,require,maxlen=10"`
+	Age     int32   `json:"age" ensure:"int32,between=0 150"`
+	Color   string  `json:"color,omitempty" ensure:"lower,oneof=red green blue"`
+	Website *string `ensure:"url=https"`
+	Notes   string  `json:"notes"`
+}
+
+func TestForStruct(t *testing.T) {
+	re := ensure.ForStruct[registryPerson]()
+
+	record := map[string]any{"name": " Jack ", "age": "42", "color": "RED", "Website": "https://example.com", "notes": 1}
+	_, err := re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":    "Jack",
+		"age":     int32(42),
+		"color":   "red",
+		"Website": "https://example.com",
+		"notes":   1,
+	}, record)
+
+	_, err = re.Ensure(map[string]any{"name": "", "age": "200", "color": "pink", "Website": "http://example.com"})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"name"}), 1)
+	assert.Len(t, etErr.Get([]any{"age"}), 1)
+	assert.Len(t, etErr.Get([]any{"color"}), 1)
+	assert.Len(t, etErr.Get([]any{"Website"}), 1)
+
+	var person registryPerson
+	err = re.EnsureInto(map[string]any{"name": "Jill", "age": 30, "Website": ""}, &person)
+	require.NoError(t, err)
+	assert.Equal(t, registryPerson{Name: "Jill", Age: 30}, person)
+}
+
+func TestForStructRegistry(t *testing.T) {
+	registry := ensure.NewRegistry()
+	registry.Register("slug", func(args ...string) ensure.Ensurer {
+		return ensure.EnsurerFunc(func(value any) (any, error) {
+			if s, ok := value.(string); ok {
+				return strings.ReplaceAll(strings.ToLower(s), " ", "-"), nil
+			}
+			return value, nil
+		})
+	})
+
+	type article struct {
+		Slug string `json:"slug" ensure:"singleline,slug"`
+	}
+
+	record := map[string]any{"slug": "Hello World"}
+	_, err := ensure.ForStructRegistry[article](registry).Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world", record["slug"])
+
+	assert.Panics(t, func() { ensure.ForStruct[article]() })
+	assert.Panics(t, func() { ensure.ForStruct[string]() })
+	assert.Panics(t, func() {
+		ensure.ForStruct[struct {
+			Name string `ensure:"maxlen=abc"`
+		}]()
+	})
+	assert.Panics(t, func() {
+		ensure.ForStruct[struct {
+			Name string `ensure:"require=1"`
+		}]()
+	})
+}
+
+func TestRegistryParse(t *testing.T) {
+	registry := ensure.NewRegistry()
+
+	ensurers, err := registry.Parse("singleline, nilifyempty, require, maxlen=5")
+	require.NoError(t, err)
+	require.Len(t, ensurers, 4)
+
+	value, err := ensure.All(ensurers...).Ensure("  abc  ")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", value)
+
+	_, err = ensure.All(ensurers...).Ensure("abcdef")
+	assert.ErrorIs(t, err, ensure.ErrTooLong)
+
+	ensurers, err = registry.Parse("")
+	require.NoError(t, err)
+	assert.Empty(t, ensurers)
+
+	for i, rules := range []string{"nope", "maxlen", "maxlen=abc", "between=1", "require=1", "min=abc"} {
+		_, err := registry.Parse(rules)
+		assert.Errorf(t, err, "%d", i)
+	}
+
+	e, err := registry.Lookup("between", "1", "10")
+	require.NoError(t, err)
+	_, err = e.Ensure(11)
+	assert.Error(t, err)
+
+	_, err = registry.Lookup("nope")
+	assert.Error(t, err)
+
+	e, err = registry.Lookup("match", "^[a-z]+ [a-z]+$")
+	require.NoError(t, err)
+	_, err = e.Ensure("jack christensen")
+	assert.NoError(t, err)
+	_, err = e.Ensure("jack")
+	assert.Error(t, err)
+	_, err = registry.Lookup("match", "(")
+	assert.Error(t, err)
+
+	e, err = registry.Lookup("match", "^[a-z]+$", "8")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"pattern": "^[a-z]+$", "max_bytes": 8}, ensure.Describe(e).Params)
+	_, err = e.Ensure("christensen")
+	assert.Error(t, err)
+	for _, args := range [][]string{{"^a$", "x"}, {"^a$", "0"}, {"^a$", "1", "2"}} {
+		_, err = registry.Lookup("match", args...)
+		assert.Errorf(t, err, "%v", args)
+	}
+
+	names := registry.Names()
+	assert.Contains(t, names, "singleline")
+	assert.Contains(t, names, "maxlen")
+	assert.IsIncreasing(t, names)
+}
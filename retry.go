@@ -0,0 +1,95 @@
+package ensure
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryPolicy configures Retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls including the first. It defaults to 3.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles after each retry up to MaxBackoff. It defaults to 50ms.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries. 0 means no maximum.
+	MaxBackoff time.Duration
+
+	// Retryable returns true if err is transient and the call should be retried. It defaults to IsRetryable.
+	Retryable func(err error) bool
+
+	// WarnOnFailure returns an error matching ErrNotChecked instead of the last error if every attempt failed with a
+	// retryable error, so the field is reported as a warning by CollectWarnings instead of failing.
+	WarnOnFailure bool
+}
+
+// IsRetryable is the default error classification of Retry and Breaker. An *Error, such as "taken", is an answer about
+// the value and a *RecordErrors is the failure of a nested record so both are terminal. context.Canceled is terminal.
+// All other errors, such as network and database errors and context.DeadlineExceeded, are retryable.
+func IsRetryable(err error) bool {
+	var ensureErr *Error
+	if errors.As(err, &ensureErr) {
+		return false
+	}
+	var recordErrs *RecordErrors
+	if errors.As(err, &recordErrs) {
+		return false
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// Retry returns a Ensurer that calls inner until it succeeds, fails with an error that is not retryable, or has been
+// called policy.MaxAttempts times. It is intended for ensurers backed by a database or API so transient failures do
+// not fail the field. Retries wait with exponential backoff and stop early when the context of the record is done. The
+// error of the last attempt is returned.
+func Retry(inner Ensurer, policy RetryPolicy) Ensurer {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 50 * time.Millisecond
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = IsRetryable
+	}
+
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		backoff := policy.Backoff
+		for attempt := 1; ; attempt++ {
+			result, err := ensureContext(ctx, inner, value)
+			if err == nil || !policy.Retryable(err) {
+				return result, err
+			}
+
+			if attempt == policy.MaxAttempts || !sleepContext(ctx, backoff) {
+				if policy.WarnOnFailure {
+					msg := "was not checked: " + err.Error()
+					return nil, NewError("not_checked", msg, map[string]any{"reason": "retry"})
+				}
+				return nil, err
+			}
+
+			backoff *= 2
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+	})
+
+	return describe("retry", map[string]any{"ensurers": []Ensurer{inner}, "max_attempts": policy.MaxAttempts}, ensurer)
+}
+
+// sleepContext waits for d. It returns false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
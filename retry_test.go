@@ -0,0 +1,75 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("connection reset")
+	errTaken := ensure.NewError("taken", "is already taken", nil)
+
+	backoff := ensure.RetryPolicy{Backoff: time.Millisecond}
+
+	tests := []struct {
+		failures []error
+		policy   ensure.RetryPolicy
+		calls    int
+		err      error
+	}{
+		{nil, ensure.RetryPolicy{}, 1, nil},
+		{[]error{errTransient, errTransient}, backoff, 3, nil},
+		{[]error{errTransient, errTransient, errTransient}, backoff, 3, errTransient},
+		{[]error{errTransient}, ensure.RetryPolicy{MaxAttempts: 1}, 1, errTransient},
+		{[]error{errTaken}, backoff, 1, errTaken},
+		{[]error{context.Canceled}, backoff, 1, context.Canceled},
+		{[]error{errTransient}, ensure.RetryPolicy{MaxAttempts: 1, WarnOnFailure: true}, 1, ensure.ErrNotChecked},
+		{[]error{errTaken}, ensure.RetryPolicy{MaxAttempts: 1, WarnOnFailure: true}, 1, errTaken},
+		{
+			[]error{errTaken, errTaken},
+			ensure.RetryPolicy{Backoff: time.Millisecond, Retryable: func(error) bool { return true }},
+			3,
+			nil,
+		},
+	}
+
+	for i, tt := range tests {
+		calls := 0
+		flaky := ensure.EnsurerFunc(func(value any) (any, error) {
+			calls++
+			if calls <= len(tt.failures) {
+				return nil, tt.failures[calls-1]
+			}
+			return value, nil
+		})
+
+		value, err := ensure.Retry(flaky, tt.policy).Ensure("x")
+		assert.Equalf(t, tt.calls, calls, "%d", i)
+		if tt.err == nil {
+			assert.NoErrorf(t, err, "%d", i)
+			assert.Equalf(t, "x", value, "%d", i)
+		} else {
+			assert.ErrorIsf(t, err, tt.err, "%d", i)
+			assert.Nilf(t, value, "%d", i)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	failing := ensure.EnsurerFunc(func(value any) (any, error) { return nil, errTransient })
+	start := time.Now()
+	record := ensure.GetterSetterMap{"email": "jack@example.com"}
+	err := ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", ensure.Retry(failing, ensure.RetryPolicy{MaxAttempts: 100, Backoff: time.Second}))
+	})
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.ErrorIs(t, recordErrs.ByField()["email"][0].Err, errTransient)
+}
@@ -0,0 +1,188 @@
+package ensure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var rruleFrequencies = map[string]struct{}{
+	"SECONDLY": {},
+	"MINUTELY": {},
+	"HOURLY":   {},
+	"DAILY":    {},
+	"WEEKLY":   {},
+	"MONTHLY":  {},
+	"YEARLY":   {},
+}
+
+var rruleWeekdays = map[string]struct{}{
+	"SU": {},
+	"MO": {},
+	"TU": {},
+	"WE": {},
+	"TH": {},
+	"FR": {},
+	"SA": {},
+}
+
+// rruleIntegerLists maps the BYxxx rule parts that are lists of integers to their allowed ranges. signed is true if the
+// values may be negative.
+var rruleIntegerLists = map[string]struct {
+	min, max int
+	signed   bool
+}{
+	"BYSECOND":   {0, 60, false},
+	"BYMINUTE":   {0, 59, false},
+	"BYHOUR":     {0, 23, false},
+	"BYMONTHDAY": {1, 31, true},
+	"BYYEARDAY":  {1, 366, true},
+	"BYWEEKNO":   {1, 53, true},
+	"BYMONTH":    {1, 12, false},
+	"BYSETPOS":   {1, 366, true},
+}
+
+// RRule returns a Ensurer that validates value is an iCalendar (RFC 5545) recurrence rule such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE". An optional "RRULE:" prefix is allowed. If allowedParts are given then only
+// those rule parts (in addition to FREQ, which is always required) are accepted. If value is nil then nil is returned.
+// If value is not a string then an error is returned.
+func RRule(allowedParts ...string) Ensurer {
+	var allowed map[string]struct{}
+	if len(allowedParts) > 0 {
+		allowed = make(map[string]struct{}, len(allowedParts)+1)
+		allowed["FREQ"] = struct{}{}
+		for _, part := range allowedParts {
+			allowed[strings.ToUpper(part)] = struct{}{}
+		}
+	}
+
+	return describe("rrule", map[string]any{"allowed_parts": allowedParts}, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, NewError("not_a_string", "not a string", nil)
+		}
+
+		err := validateRRule(strings.TrimSpace(s), allowed)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}))
+}
+
+func invalidRRule(message string, part string) *Error {
+	var params map[string]any
+	if part != "" {
+		params = map[string]any{"part": part}
+	}
+	return NewError("invalid_rrule", message, params)
+}
+
+func validateRRule(s string, allowed map[string]struct{}) error {
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return invalidRRule("not a valid recurrence rule", "")
+	}
+
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(s, ";") {
+		name, partValue, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || partValue == "" {
+			return invalidRRule("not a valid recurrence rule", name)
+		}
+
+		if _, ok := seen[name]; ok {
+			return invalidRRule(fmt.Sprintf("%s is specified more than once", name), name)
+		}
+		seen[name] = struct{}{}
+
+		if allowed != nil {
+			if _, ok := allowed[name]; !ok {
+				return invalidRRule(fmt.Sprintf("%s is not allowed", name), name)
+			}
+		}
+
+		partValue = strings.ToUpper(partValue)
+		if !validRRulePart(name, partValue) {
+			return invalidRRule(fmt.Sprintf("%s is not valid", name), name)
+		}
+	}
+
+	if _, ok := seen["FREQ"]; !ok {
+		return invalidRRule("FREQ is required", "FREQ")
+	}
+
+	_, hasUntil := seen["UNTIL"]
+	_, hasCount := seen["COUNT"]
+	if hasUntil && hasCount {
+		return invalidRRule("UNTIL and COUNT cannot both be specified", "COUNT")
+	}
+
+	return nil
+}
+
+func validRRulePart(name, value string) bool {
+	switch name {
+	case "FREQ":
+		_, ok := rruleFrequencies[value]
+		return ok
+	case "UNTIL":
+		for _, format := range []string{"20060102", "20060102T150405", "20060102T150405Z"} {
+			if _, err := time.Parse(format, value); err == nil {
+				return true
+			}
+		}
+		return false
+	case "COUNT", "INTERVAL":
+		n, err := strconv.Atoi(value)
+		return err == nil && n > 0
+	case "WKST":
+		_, ok := rruleWeekdays[value]
+		return ok
+	case "BYDAY":
+		for _, day := range strings.Split(value, ",") {
+			if len(day) < 2 {
+				return false
+			}
+			if _, ok := rruleWeekdays[day[len(day)-2:]]; !ok {
+				return false
+			}
+			if ordinal := day[:len(day)-2]; ordinal != "" {
+				n, err := strconv.Atoi(ordinal)
+				if err != nil || n == 0 || n < -53 || n > 53 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	limits, ok := rruleIntegerLists[name]
+	if !ok {
+		return false
+	}
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return false
+		}
+		if limits.signed && n < 0 {
+			n = -n
+		} else if item[0] == '-' {
+			return false
+		}
+		if n < limits.min || n > limits.max {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRRule(t *testing.T) {
+	tests := []struct {
+		value    any
+		allowed  []string
+		expected any
+		success  bool
+	}{
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", nil, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", true},
+		{"RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=10", nil, "RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=10", true},
+		{"freq=daily;until=20231231T235959Z", nil, "freq=daily;until=20231231T235959Z", true},
+		{"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=-1", nil, "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=-1", true},
+		{"INTERVAL=2", nil, nil, false},
+		{"FREQ=FORTNIGHTLY", nil, nil, false},
+		{"FREQ=DAILY;COUNT=0", nil, nil, false},
+		{"FREQ=DAILY;COUNT=5;UNTIL=20231231", nil, nil, false},
+		{"FREQ=DAILY;FREQ=WEEKLY", nil, nil, false},
+		{"FREQ=WEEKLY;BYDAY=XX", nil, nil, false},
+		{"FREQ=YEARLY;BYMONTH=13", nil, nil, false},
+		{"FREQ=DAILY;BYHOUR=-1", nil, nil, false},
+		{"FREQ=DAILY;X-NAME=foo", nil, nil, false},
+		{"FREQ=WEEKLY;BYDAY=MO", []string{"INTERVAL", "BYDAY"}, "FREQ=WEEKLY;BYDAY=MO", true},
+		{"FREQ=WEEKLY;BYSETPOS=1", []string{"INTERVAL", "BYDAY"}, nil, false},
+		{"", nil, nil, false},
+		{42, nil, nil, false},
+		{nil, nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.RRule(tt.allowed...).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
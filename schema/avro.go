@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// avroPrimitives are the rules of the Avro primitive types that have an equivalent.
+var avroPrimitives = map[string]Rule{
+	"string":  {Name: "string"},
+	"int":     {Name: "int32"},
+	"long":    {Name: "int64"},
+	"float":   {Name: "float32"},
+	"double":  {Name: "float64"},
+	"boolean": {Name: "bool"},
+}
+
+// FromAvro returns a Schema of the fields of the Avro record schema data in JSON so records received as JSON can be
+// ensured against the same definition as the Avro encoded ones. The subset of Avro that is translated is:
+//
+//   - The primitive types string, int, long, float, double, and boolean become string, int32, int64, float32, float64,
+//     and bool.
+//   - The logical types uuid and decimal become uuid and decimal.
+//   - An enum becomes string and oneof its symbols. An enum defined by an earlier field may be referenced by name.
+//   - A union of null and one other type is optional. A field of any other type without a default is followed by
+//     notnil.
+//   - The doc of a field becomes the "doc" metadata of the field.
+//
+// Any other type, such as bytes, fixed, array, map, or a nested record, is an error that names the field.
+func FromAvro(data []byte) (*Schema, error) {
+	var record struct {
+		Type   string `json:"type"`
+		Fields []struct {
+			Name    string          `json:"name"`
+			Type    json.RawMessage `json:"type"`
+			Doc     string          `json:"doc"`
+			Default json.RawMessage `json:"default"`
+		} `json:"fields"`
+	}
+	err := json.Unmarshal(data, &record)
+	if err != nil {
+		return nil, err
+	}
+	if record.Type != "record" {
+		return nil, errors.New("not an Avro record schema")
+	}
+
+	ai := &avroImporter{named: make(map[string][]Rule)}
+	s := &Schema{}
+	for _, f := range record.Fields {
+		rules, nullable, err := ai.fieldType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if !nullable && f.Default == nil {
+			rules = append(rules, Rule{Name: "notnil"})
+		}
+
+		field := Field{Name: f.Name, Rules: rules}
+		if f.Doc != "" {
+			field.Metadata = map[string]any{"doc": f.Doc}
+		}
+		s.Fields = append(s.Fields, field)
+	}
+
+	return s, nil
+}
+
+// avroImporter translates the types of an Avro record schema.
+type avroImporter struct {
+	// named are the rules of the named types defined so far.
+	named map[string][]Rule
+}
+
+// fieldType returns the rules of the Avro type t. nullable is true if t is a union with null.
+func (ai *avroImporter) fieldType(t json.RawMessage) (rules []Rule, nullable bool, err error) {
+	t = bytes.TrimSpace(t)
+	if len(t) > 0 && t[0] == '[' {
+		var union []json.RawMessage
+		err := json.Unmarshal(t, &union)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var other []json.RawMessage
+		for _, u := range union {
+			if string(bytes.TrimSpace(u)) == `"null"` {
+				nullable = true
+			} else {
+				other = append(other, u)
+			}
+		}
+		if len(other) != 1 {
+			return nil, false, errors.New("unions other than null and one type are not supported")
+		}
+
+		rules, err := ai.typ(other[0])
+		return rules, nullable, err
+	}
+
+	rules, err = ai.typ(t)
+	return rules, false, err
+}
+
+// typ returns the rules of the Avro type t that is not a union.
+func (ai *avroImporter) typ(t json.RawMessage) ([]Rule, error) {
+	var name string
+	if json.Unmarshal(t, &name) == nil {
+		if rule, ok := avroPrimitives[name]; ok {
+			return []Rule{rule}, nil
+		}
+		if rules, ok := ai.named[name]; ok {
+			return rules, nil
+		}
+		return nil, fmt.Errorf("type %s is not supported", name)
+	}
+
+	var complex struct {
+		Type        string   `json:"type"`
+		Name        string   `json:"name"`
+		LogicalType string   `json:"logicalType"`
+		Symbols     []string `json:"symbols"`
+	}
+	err := json.Unmarshal(t, &complex)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case complex.LogicalType == "uuid" && complex.Type == "string":
+		return []Rule{{Name: "uuid"}}, nil
+	case complex.LogicalType == "decimal" && (complex.Type == "bytes" || complex.Type == "fixed"):
+		return []Rule{{Name: "decimal"}}, nil
+	case complex.Type == "enum":
+		if len(complex.Symbols) == 0 {
+			return nil, errors.New("enum has no symbols")
+		}
+		rules := []Rule{{Name: "string"}, {Name: "oneof", Args: complex.Symbols}}
+		if complex.Name != "" {
+			ai.named[complex.Name] = rules
+		}
+		return rules, nil
+	}
+
+	if rule, ok := avroPrimitives[complex.Type]; ok {
+		return []Rule{rule}, nil
+	}
+	return nil, fmt.Errorf("type %s is not supported", complex.Type)
+}
@@ -0,0 +1,82 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure/schema"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const personAvro = `{
+  "type": "record",
+  "name": "Person",
+  "fields": [
+    {"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+    {"name": "name", "type": "string", "doc": "Full name"},
+    {"name": "age", "type": ["null", "int"]},
+    {"name": "score", "type": "double", "default": 0},
+    {"name": "color", "type": {"type": "enum", "name": "Color", "symbols": ["red", "green", "blue"]}},
+    {"name": "accent", "type": ["null", "Color"]},
+    {"name": "active", "type": "boolean"},
+    {"name": "balance", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}}
+  ]
+}`
+
+func TestFromAvro(t *testing.T) {
+	s, err := schema.FromAvro([]byte(personAvro))
+	require.NoError(t, err)
+
+	colors := []schema.Rule{{Name: "string"}, {Name: "oneof", Args: []string{"red", "green", "blue"}}}
+	expected := &schema.Schema{Fields: []schema.Field{
+		{Name: "id", Rules: []schema.Rule{{Name: "uuid"}, {Name: "notnil"}}},
+		{
+			Name:     "name",
+			Rules:    []schema.Rule{{Name: "string"}, {Name: "notnil"}},
+			Metadata: map[string]any{"doc": "Full name"},
+		},
+		{Name: "age", Rules: []schema.Rule{{Name: "int32"}}},
+		{Name: "score", Rules: []schema.Rule{{Name: "float64"}}},
+		{Name: "color", Rules: append(colors[:2:2], schema.Rule{Name: "notnil"})},
+		{Name: "accent", Rules: colors},
+		{Name: "active", Rules: []schema.Rule{{Name: "bool"}, {Name: "notnil"}}},
+		{Name: "balance", Rules: []schema.Rule{{Name: "decimal"}, {Name: "notnil"}}},
+	}}
+	assert.Equal(t, expected, s)
+
+	re, err := s.RecordEnsurer(nil)
+	require.NoError(t, err)
+
+	record := map[string]any{
+		"id": "b4c2f0d6-7a51-4e0f-9f3b-3f2a1c9d8e7a", "name": "Jack", "age": "42", "color": "red", "active": "true",
+		"balance": "12.50",
+	}
+	_, err = re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), record["age"])
+	assert.Equal(t, true, record["active"])
+
+	_, err = re.Ensure(map[string]any{
+		"id": "x", "age": "x", "color": "pink", "accent": "pink", "active": true, "balance": 1,
+	})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	for _, field := range []string{"id", "name", "age", "color", "accent"} {
+		assert.Lenf(t, etErr.Get([]any{field}), 1, "%s", field)
+	}
+
+	for i, data := range []string{
+		`{"type": "enum", "name": "Color", "symbols": ["red"]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": "bytes"}]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": {"type": "array", "items": "string"}}]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": {"type": "record", "name": "A", "fields": []}}]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": ["null", "string", "int"]}]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": "Color"}]}`,
+		`{"type": "record", "fields": [{"name": "a", "type": {"type": "enum", "name": "A", "symbols": []}}]}`,
+		`{"type": "record"`,
+	} {
+		_, err := schema.FromAvro([]byte(data))
+		assert.Errorf(t, err, "%d", i)
+	}
+}
@@ -0,0 +1,432 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// cueTypes are the rules of the CUE types that have an equivalent.
+var cueTypes = map[string]string{
+	"string": "string", "bool": "bool", "number": "decimal", "float": "float64", "int": "int64",
+	"int8": "int8", "int16": "int16", "int32": "int32", "int64": "int64",
+	"uint8": "uint8", "uint16": "uint16", "uint32": "uint32", "uint64": "uint64",
+	"float32": "float32", "float64": "float64",
+}
+
+// cueBounds are the rules of the CUE bound operators.
+var cueBounds = map[string]string{">=": "min", ">": "gt", "<=": "max", "<": "lt"}
+
+// cueValidators are the rules of the functions of the CUE strings package that have an equivalent.
+var cueValidators = map[string]string{
+	"strings.MinRunes": "minlen", "strings.MaxRunes": "maxlen",
+	"strings.HasPrefix": "prefix", "strings.HasSuffix": "suffix", "strings.Contains": "contains",
+}
+
+// FromCUE returns a Schema of the fields of definition, such as "#Person", in the CUE source src. No CUE evaluation is
+// done. Each field must be a conjunction of constraints written directly in the definition. The subset of CUE that is
+// translated is:
+//
+//   - The types string, bool, number, float, int, and the sized integer and float types. number becomes decimal and
+//     int becomes int64.
+//   - The bounds >=, >, <=, and < of a number become min, gt, max, and lt.
+//   - =~ becomes match.
+//   - strings.MinRunes, strings.MaxRunes, strings.HasPrefix, strings.HasSuffix, and strings.Contains become minlen,
+//     maxlen, prefix, suffix, and contains.
+//   - A disjunction of strings, which may be marked as the default with *, becomes string and oneof the strings.
+//   - A disjunction with null is optional. A field that is not optional, marked with ?, is followed by notnil.
+//
+// The package clause, imports, and comments are skipped. Any other constraint, such as a nested struct, a list, a
+// reference to another definition, or !=, is an error that names the field.
+func FromCUE(src []byte, definition string) (*Schema, error) {
+	p := &cueParser{src: string(src), line: 1}
+
+	for {
+		tok := p.next()
+		switch {
+		case tok.kind == cueEOF:
+			return nil, fmt.Errorf("definition %s not found", definition)
+		case tok.kind == cueIdent && (tok.text == "package" || tok.text == "import") && p.atLineStart(tok):
+			p.skipClause(tok.text)
+		case tok.kind == cueIdent && tok.text == definition:
+			if t := p.next(); t.text != ":" {
+				return nil, p.errorf(t, "expected : after %s", definition)
+			}
+			if t := p.next(); t.text != "{" {
+				return nil, p.errorf(t, "expected { after %s:", definition)
+			}
+			return p.structFields()
+		case tok.text == "{":
+			p.skipBlock()
+		case tok.kind == cueError:
+			return nil, p.errorf(tok, "%s", tok.text)
+		}
+	}
+}
+
+type cueTokenKind int
+
+const (
+	cueEOF cueTokenKind = iota
+	cueError
+	cueNewline
+	cueIdent
+	cueString
+	cueNumber
+	cuePunct
+)
+
+type cueToken struct {
+	kind cueTokenKind
+	text string
+	line int
+}
+
+// cueParser is a tokenizer and parser of the subset of CUE translated by FromCUE.
+type cueParser struct {
+	src  string
+	pos  int
+	line int
+
+	peeked *cueToken
+}
+
+func (p *cueParser) errorf(tok cueToken, format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", tok.line, fmt.Sprintf(format, args...))
+}
+
+// atLineStart returns true if tok is the first token of its line.
+func (p *cueParser) atLineStart(tok cueToken) bool {
+	i := p.pos - len(tok.text) - 1
+	for i >= 0 && (p.src[i] == ' ' || p.src[i] == '\t') {
+		i--
+	}
+	return i < 0 || p.src[i] == '\n'
+}
+
+// peek returns the next token without consuming it.
+func (p *cueParser) peek() cueToken {
+	if p.peeked == nil {
+		tok := p.scan()
+		p.peeked = &tok
+	}
+	return *p.peeked
+}
+
+// next consumes and returns the next token.
+func (p *cueParser) next() cueToken {
+	tok := p.peek()
+	p.peeked = nil
+	return tok
+}
+
+// skipNewlines consumes newline tokens.
+func (p *cueParser) skipNewlines() {
+	for p.peek().kind == cueNewline {
+		p.next()
+	}
+}
+
+// scan returns the next token in src. Comments are skipped.
+func (p *cueParser) scan() cueToken {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\n':
+			p.pos++
+			p.line++
+			return cueToken{kind: cueNewline, text: "\n", line: p.line - 1}
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return p.scanToken()
+		}
+	}
+	return cueToken{kind: cueEOF, line: p.line}
+}
+
+// scanToken scans a token that is not whitespace or a comment.
+func (p *cueParser) scanToken() cueToken {
+	start, rest := p.pos, p.src[p.pos:]
+	c := rest[0]
+
+	switch {
+	case c == '"':
+		if strings.HasPrefix(rest, `"""`) {
+			return cueToken{kind: cueError, text: "multiline strings are not supported", line: p.line}
+		}
+		i := 1
+		for i < len(rest) && rest[i] != '"' && rest[i] != '\n' {
+			if rest[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(rest) || rest[i] != '"' {
+			return cueToken{kind: cueError, text: "unterminated string", line: p.line}
+		}
+		p.pos += i + 1
+		s, err := strconv.Unquote(rest[:i+1])
+		if err != nil {
+			return cueToken{kind: cueError, text: "invalid string " + rest[:i+1], line: p.line}
+		}
+		return cueToken{kind: cueString, text: s, line: p.line}
+
+	case c == '#' || c == '_' || c == '$' || unicode.IsLetter(rune(c)):
+		p.pos++
+		for p.pos < len(p.src) {
+			c := rune(p.src[p.pos])
+			if c != '_' && c != '$' && c != '#' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+				break
+			}
+			p.pos++
+		}
+		return cueToken{kind: cueIdent, text: p.src[start:p.pos], line: p.line}
+
+	case unicode.IsDigit(rune(c)) || (c == '-' && len(rest) > 1 && unicode.IsDigit(rune(rest[1]))):
+		p.pos++
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789._eE+-xXabcdefABCDEF", rune(p.src[p.pos])) {
+			if (p.src[p.pos] == '+' || p.src[p.pos] == '-') && !strings.ContainsRune("eE", rune(p.src[p.pos-1])) {
+				break
+			}
+			p.pos++
+		}
+		return cueToken{kind: cueNumber, text: strings.ReplaceAll(p.src[start:p.pos], "_", ""), line: p.line}
+	}
+
+	for _, op := range []string{"...", ">=", "<=", "=~", "!~", "!=", "=="} {
+		if strings.HasPrefix(rest, op) {
+			p.pos += len(op)
+			return cueToken{kind: cuePunct, text: op, line: p.line}
+		}
+	}
+	p.pos++
+	return cueToken{kind: cuePunct, text: string(c), line: p.line}
+}
+
+// skipClause skips a package or import clause.
+func (p *cueParser) skipClause(keyword string) {
+	if keyword == "import" && p.peek().text == "(" {
+		for tok := p.next(); tok.kind != cueEOF && tok.text != ")"; tok = p.next() {
+		}
+		return
+	}
+	for tok := p.next(); tok.kind != cueEOF && tok.kind != cueNewline; tok = p.next() {
+	}
+}
+
+// skipBlock skips to the } that closes a { that was just consumed.
+func (p *cueParser) skipBlock() {
+	depth := 1
+	for depth > 0 {
+		switch tok := p.next(); {
+		case tok.kind == cueEOF:
+			return
+		case tok.text == "{":
+			depth++
+		case tok.text == "}":
+			depth--
+		}
+	}
+}
+
+// structFields parses the fields of a struct up to its closing }.
+func (p *cueParser) structFields() (*Schema, error) {
+	s := &Schema{}
+	seen := make(map[string]bool)
+
+	for {
+		p.skipNewlines()
+		tok := p.next()
+		switch {
+		case tok.text == "}":
+			return s, nil
+		case tok.text == ",":
+			continue
+		case tok.kind == cueError:
+			return nil, p.errorf(tok, "%s", tok.text)
+		case tok.kind != cueIdent && tok.kind != cueString:
+			return nil, p.errorf(tok, "expected a field")
+		}
+
+		name := tok.text
+		optional := false
+		if t := p.peek(); t.text == "?" || t.text == "!" {
+			optional = t.text == "?"
+			p.next()
+		}
+		if t := p.next(); t.text != ":" {
+			return nil, p.errorf(t, "%s: expected :", name)
+		}
+		if seen[name] {
+			return nil, p.errorf(tok, "%s: defined more than once", name)
+		}
+		seen[name] = true
+
+		rules, nullable, err := p.expression()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if !optional && !nullable {
+			rules = append(rules, Rule{Name: "notnil"})
+		}
+		s.Fields = append(s.Fields, Field{Name: name, Rules: rules})
+
+		if t := p.peek(); t.kind != cueNewline && t.text != "," && t.text != "}" {
+			return nil, p.errorf(t, "%s: unexpected %q", name, t.text)
+		}
+	}
+}
+
+// expression parses the constraint of a field. It is either a conjunction or a disjunction of strings and null.
+func (p *cueParser) expression() (rules []Rule, nullable bool, err error) {
+	var conjunctions [][]cueToken
+	for {
+		conjunction, err := p.conjunction()
+		if err != nil {
+			return nil, false, err
+		}
+		conjunctions = append(conjunctions, conjunction)
+
+		if p.peek().text != "|" {
+			break
+		}
+		p.next()
+		p.skipNewlines()
+	}
+
+	var constraint []cueToken
+	var allowed []string
+	for _, c := range conjunctions {
+		switch {
+		case len(c) == 1 && c[0].kind == cueIdent && c[0].text == "null":
+			nullable = true
+		case len(c) == 1 && c[0].kind == cueString:
+			allowed = append(allowed, c[0].text)
+		case len(conjunctions) == 1 || constraint == nil && len(conjunctions) == 2:
+			constraint = c
+		default:
+			return nil, false, p.errorf(c[0], "disjunctions other than of strings and null are not supported")
+		}
+	}
+
+	if len(allowed) > 0 {
+		if constraint != nil {
+			return nil, false, p.errorf(constraint[0], "disjunctions other than of strings and null are not supported")
+		}
+		return []Rule{{Name: "string"}, {Name: "oneof", Args: allowed}}, nullable, nil
+	}
+	if constraint == nil {
+		return nil, false, errors.New("null is the only value")
+	}
+
+	rules, err = p.constraints(constraint)
+	return rules, nullable, err
+}
+
+// conjunction returns the terms of a conjunction. A term is a single token except that a bound is its operator and
+// value and a validator is its name, "(", argument, and ")". A default marker * is dropped.
+func (p *cueParser) conjunction() ([]cueToken, error) {
+	var terms []cueToken
+	for {
+		tok := p.next()
+		if tok.text == "*" {
+			tok = p.next()
+		}
+
+		switch {
+		case tok.kind == cueError:
+			return nil, p.errorf(tok, "%s", tok.text)
+		case tok.kind == cueIdent && p.peek().text == ".":
+			p.next()
+			fn := p.next()
+			tok.text += "." + fn.text
+			lparen, arg, rparen := p.next(), p.next(), p.next()
+			if lparen.text != "(" || rparen.text != ")" {
+				return nil, p.errorf(tok, "%s must have one argument", tok.text)
+			}
+			terms = append(terms, tok, arg)
+		case tok.kind == cuePunct && (cueBounds[tok.text] != "" || tok.text == "=~"):
+			terms = append(terms, tok, p.next())
+		case tok.kind == cueIdent || tok.kind == cueString || tok.kind == cueNumber:
+			terms = append(terms, tok)
+		default:
+			return nil, p.errorf(tok, "%q is not supported", tok.text)
+		}
+
+		if p.peek().text != "&" {
+			return terms, nil
+		}
+		p.next()
+		p.skipNewlines()
+	}
+}
+
+// constraints returns the rules of the terms of a conjunction. The type is first. If there is no type then it is
+// implied by the other terms.
+func (p *cueParser) constraints(terms []cueToken) ([]Rule, error) {
+	var typ string
+	var rules []Rule
+
+	for i := 0; i < len(terms); i++ {
+		tok := terms[i]
+		switch {
+		case tok.kind == cueIdent && cueTypes[tok.text] != "":
+			if typ != "" {
+				return nil, p.errorf(tok, "more than one type")
+			}
+			typ = cueTypes[tok.text]
+
+		case tok.kind == cueIdent && cueValidators[tok.text] != "":
+			i++
+			arg := terms[i]
+			if (tok.text == "strings.MinRunes" || tok.text == "strings.MaxRunes") != (arg.kind == cueNumber) {
+				return nil, p.errorf(arg, "invalid argument of %s", tok.text)
+			}
+			rules = append(rules, Rule{Name: cueValidators[tok.text], Args: []string{arg.text}})
+			if typ == "" {
+				typ = "string"
+			}
+
+		case tok.text == "=~":
+			i++
+			if terms[i].kind != cueString {
+				return nil, p.errorf(terms[i], "=~ requires a string")
+			}
+			rules = append(rules, Rule{Name: "match", Args: []string{terms[i].text}})
+			if typ == "" {
+				typ = "string"
+			}
+
+		case cueBounds[tok.text] != "":
+			i++
+			if terms[i].kind != cueNumber {
+				return nil, p.errorf(terms[i], "%s requires a number", tok.text)
+			}
+			rules = append(rules, Rule{Name: cueBounds[tok.text], Args: []string{terms[i].text}})
+			if typ == "" {
+				typ = "decimal"
+			}
+
+		case tok.kind == cueString:
+			rules = append(rules, Rule{Name: "oneof", Args: []string{tok.text}})
+			if typ == "" {
+				typ = "string"
+			}
+
+		default:
+			return nil, p.errorf(tok, "%q is not supported", tok.text)
+		}
+	}
+
+	if typ == "" {
+		return nil, errors.New("no type")
+	}
+	return append([]Rule{{Name: typ}}, rules...), nil
+}
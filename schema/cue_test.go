@@ -0,0 +1,102 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure/schema"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const personCUE = `package people
+
+import "strings"
+
+// #Person is a person.
+#Address: {
+	city: string
+}
+
+#Person: {
+	name:     string & strings.MinRunes(1) & strings.MaxRunes(10) // display name
+	age?:     int & >=0 & <=150
+	score:    null | float64 & >0
+	color:    *"red" | "green" | "blue"
+	code!:    =~"^[A-Z]{3}$"
+	website?: strings.HasPrefix("https://")
+	"is-admin"?: bool
+}
+`
+
+func TestFromCUE(t *testing.T) {
+	s, err := schema.FromCUE([]byte(personCUE), "#Person")
+	require.NoError(t, err)
+
+	expected := &schema.Schema{Fields: []schema.Field{
+		{Name: "name", Rules: []schema.Rule{
+			{Name: "string"},
+			{Name: "minlen", Args: []string{"1"}},
+			{Name: "maxlen", Args: []string{"10"}},
+			{Name: "notnil"},
+		}},
+		{Name: "age", Rules: []schema.Rule{
+			{Name: "int64"}, {Name: "min", Args: []string{"0"}}, {Name: "max", Args: []string{"150"}},
+		}},
+		{Name: "score", Rules: []schema.Rule{{Name: "float64"}, {Name: "gt", Args: []string{"0"}}}},
+		{Name: "color", Rules: []schema.Rule{
+			{Name: "string"}, {Name: "oneof", Args: []string{"red", "green", "blue"}}, {Name: "notnil"},
+		}},
+		{Name: "code", Rules: []schema.Rule{
+			{Name: "string"}, {Name: "match", Args: []string{"^[A-Z]{3}$"}}, {Name: "notnil"},
+		}},
+		{Name: "website", Rules: []schema.Rule{{Name: "string"}, {Name: "prefix", Args: []string{"https://"}}}},
+		{Name: "is-admin", Rules: []schema.Rule{{Name: "bool"}}},
+	}}
+	assert.Equal(t, expected, s)
+
+	re, err := s.RecordEnsurer(nil)
+	require.NoError(t, err)
+
+	record := map[string]any{"name": "Jack", "age": "42", "color": "red", "code": "ABC"}
+	_, err = re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), record["age"])
+
+	_, err = re.Ensure(map[string]any{
+		"name": "", "age": "200", "score": "0", "color": "pink", "code": "abc", "website": "http://example.com",
+	})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	for _, field := range []string{"name", "age", "score", "color", "code", "website"} {
+		assert.Lenf(t, etErr.Get([]any{field}), 1, "%s", field)
+	}
+
+	s, err = schema.FromCUE([]byte(personCUE), "#Address")
+	require.NoError(t, err)
+	assert.Equal(t, &schema.Schema{Fields: []schema.Field{
+		{Name: "city", Rules: []schema.Rule{{Name: "string"}, {Name: "notnil"}}},
+	}}, s)
+
+	for i, src := range []string{
+		"#A: {a: string}",
+		"#Person: {a: {b: string}}",
+		"#Person: {a: [...string]}",
+		"#Person: {a: string & !=\"\"}",
+		"#Person: {a: #Address}",
+		"#Person: {a: string | int}",
+		"#Person: {a: string & int}",
+		"#Person: {a: null}",
+		"#Person: {a: >=\"a\"}",
+		"#Person: {a: string, a: string}",
+		"#Person: {a: strings.MinRunes(\"1\")}",
+		"#Person: {a: \"unterminated}",
+		"#Person: {\n\ta: string\n\tb: string string\n}",
+	} {
+		_, err := schema.FromCUE([]byte(src), "#Person")
+		assert.Errorf(t, err, "%d", i)
+	}
+
+	_, err = schema.FromCUE([]byte("#Person: {\n\ta: string\n\tb: [...string]\n}"), "#Person")
+	assert.EqualError(t, err, "b: line 3: \"[\" is not supported")
+}
@@ -0,0 +1,344 @@
+/*
+Package schema builds ensure.RecordEnsurer values from declarative JSON or YAML documents so validation rules can be
+changed without recompiling. A document lists fields in the order they are ensured. Each field has a list of rules
+from an ensure.Registry. A rule is either a string in the same syntax as an "ensure" struct tag or an object with a
+name and args. e.g.
+
+	fields:
+	  - name: name
+	    rules: [singleline, nilifyempty, require, maxlen=100]
+	  - name: age
+	    metadata: {section: Personal, order: 2}
+	    rules:
+	      - int32
+	      - name: between
+	        args: [0, 150]
+
+The same document in JSON is:
+
+	{"fields": [
+	  {"name": "name", "rules": ["singleline", "nilifyempty", "require", "maxlen=100"]},
+	  {"name": "age", "metadata": {"section": "Personal", "order": 2},
+	   "rules": ["int32", {"name": "between", "args": [0, 150]}]}
+	]}
+
+A Schema can be marshaled with encoding/json or gopkg.in/yaml.v3 to the same format, so rules can be stored in a
+database, edited, and loaded at runtime. A Store compiles and caches the schemas of such a source and reloads them
+while the service runs.
+
+FromAvro and FromCUE translate a subset of an Avro record schema or a CUE definition into a Schema so the definitions
+of an organization standardized on one of those schema languages can be enforced at the boundaries of a Go service.
+*/
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/ensure"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is a declarative definition of a record.
+type Schema struct {
+	Fields []Field `json:"fields" yaml:"fields"`
+}
+
+// Field is a record field and the rules that ensure it. Metadata such as a group, UI section, or order is not used to
+// ensure the field. It is reported by ensure.RecordEnsurer.Rules.
+type Field struct {
+	Name     string         `json:"name" yaml:"name"`
+	Rules    []Rule         `json:"rules" yaml:"rules"`
+	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// Rule is the name of a rule in an ensure.Registry and its arguments.
+type Rule struct {
+	Name string   `json:"name" yaml:"name"`
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// ParseJSON parses a Schema from JSON. Unknown keys are an error so misspellings are not silently ignored.
+func ParseJSON(data []byte) (*Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var s Schema
+	err := decoder.Decode(&s)
+	if err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after schema")
+	}
+
+	return &s, nil
+}
+
+// ParseYAML parses a Schema from YAML. Unknown keys are an error so misspellings are not silently ignored.
+func ParseYAML(data []byte) (*Schema, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var s Schema
+	err := decoder.Decode(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// ReadFile reads and parses the Schema in the file name. Files with a ".json" extension are parsed as JSON. All others
+// are parsed as YAML.
+func ReadFile(name string) (*Schema, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFile(name, data)
+}
+
+// parseFile parses data read from the file name as JSON if name has a ".json" extension and otherwise as YAML.
+func parseFile(name string, data []byte) (*Schema, error) {
+	var s *Schema
+	var err error
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		s, err = ParseJSON(data)
+	} else {
+		s, err = ParseYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return s, nil
+}
+
+// RecordEnsurer returns a RecordEnsurer that ensures each field of s in order with the rules from registry. If registry
+// is nil then ensure.DefaultRegistry is used. An error is returned if a field has no name, a field is defined more than
+// once, or a rule is unknown or has invalid arguments.
+func (s *Schema) RecordEnsurer(registry *ensure.Registry) (*ensure.RecordEnsurer, error) {
+	if registry == nil {
+		registry = ensure.DefaultRegistry
+	}
+
+	type field struct {
+		name     string
+		ensurers []ensure.Ensurer
+		metadata map[string]any
+	}
+	fields := make([]field, 0, len(s.Fields))
+	seen := make(map[string]struct{}, len(s.Fields))
+
+	for i, f := range s.Fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("fields[%d]: missing name", i)
+		}
+		if _, ok := seen[f.Name]; ok {
+			return nil, fmt.Errorf("%s: defined more than once", f.Name)
+		}
+		seen[f.Name] = struct{}{}
+
+		ensurers := make([]ensure.Ensurer, 0, len(f.Rules))
+		for _, rule := range f.Rules {
+			e, err := registry.Lookup(rule.Name, rule.Args...)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", f.Name, rule.Name, err)
+			}
+			ensurers = append(ensurers, e)
+		}
+
+		fields = append(fields, field{name: f.Name, ensurers: ensurers, metadata: f.Metadata})
+	}
+
+	return ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		for _, f := range fields {
+			if f.metadata != nil {
+				r.Metadata(f.name, f.metadata)
+			}
+			r.Ensure(f.name, f.ensurers...)
+		}
+	}), nil
+}
+
+// Overlay returns base with the fields of s added by ensure.RecordEnsurer.Overlay, such as the extra rules of a
+// tenant on top of the rules every tenant shares. s is compiled with registry on every call. Where s is used for many
+// records, compile it once with RecordEnsurer and overlay the result instead.
+func (s *Schema) Overlay(base *ensure.RecordEnsurer, registry *ensure.Registry) (*ensure.RecordEnsurer, error) {
+	extra, err := s.RecordEnsurer(registry)
+	if err != nil {
+		return nil, err
+	}
+	return base.Overlay(extra), nil
+}
+
+// parseRule parses a rule in the syntax of an "ensure" struct tag such as "maxlen=100" or "oneof=red green blue".
+func parseRule(s string) (Rule, error) {
+	name, argStr, _ := strings.Cut(strings.TrimSpace(s), "=")
+	if name == "" {
+		return Rule{}, errors.New("missing rule name")
+	}
+	rule := Rule{Name: name}
+	if args := strings.Fields(argStr); len(args) > 0 {
+		rule.Args = args
+	}
+	return rule, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string or an object with a name and args. Args may be
+// strings, numbers, or booleans.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		rule, err := parseRule(s)
+		if err != nil {
+			return err
+		}
+		*r = rule
+		return nil
+	}
+
+	var obj struct {
+		Name string            `json:"name"`
+		Args []json.RawMessage `json:"args"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&obj)
+	if err != nil {
+		return fmt.Errorf("rule must be a string or an object with name and args: %w", err)
+	}
+	if obj.Name == "" {
+		return errors.New("missing rule name")
+	}
+
+	rule := Rule{Name: obj.Name}
+	for _, raw := range obj.Args {
+		var arg string
+		if json.Unmarshal(raw, &arg) != nil {
+			raw = bytes.TrimSpace(raw)
+			if len(raw) == 0 || raw[0] == '{' || raw[0] == '[' || string(raw) == "null" {
+				return fmt.Errorf("%s: args must be strings, numbers, or booleans", obj.Name)
+			}
+			arg = string(raw)
+		}
+		rule.Args = append(rule.Args, arg)
+	}
+	*r = rule
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. It accepts a string or a mapping with a name and args. Args must be
+// scalars.
+func (r *Rule) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		rule, err := parseRule(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*r = rule
+		return nil
+
+	case yaml.MappingNode:
+		var rule Rule
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch key.Value {
+			case "name":
+				if value.Kind != yaml.ScalarNode {
+					return fmt.Errorf("line %d: name must be a string", value.Line)
+				}
+				rule.Name = value.Value
+			case "args":
+				if value.Kind != yaml.SequenceNode {
+					return fmt.Errorf("line %d: args must be a list", value.Line)
+				}
+				for _, arg := range value.Content {
+					if arg.Kind != yaml.ScalarNode {
+						return fmt.Errorf("line %d: args must be strings, numbers, or booleans", arg.Line)
+					}
+					rule.Args = append(rule.Args, arg.Value)
+				}
+			default:
+				return fmt.Errorf("line %d: field %s not found in rule", key.Line, key.Value)
+			}
+		}
+		if rule.Name == "" {
+			return fmt.Errorf("line %d: missing rule name", node.Line)
+		}
+		*r = rule
+		return nil
+
+	default:
+		return fmt.Errorf("line %d: rule must be a string or a mapping with name and args", node.Line)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. A rule without args is a string. Otherwise it is an object with a name and
+// args. Args that are JSON numbers or booleans are written as such so the document is natural to edit. Unmarshaling
+// the result returns the same rule.
+func (r Rule) MarshalJSON() ([]byte, error) {
+	if len(r.Args) == 0 {
+		return json.Marshal(r.Name)
+	}
+
+	args := make([]json.RawMessage, len(r.Args))
+	for i, arg := range r.Args {
+		if isJSONScalar(arg) {
+			args[i] = json.RawMessage(arg)
+			continue
+		}
+		buf, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = buf
+	}
+
+	return json.Marshal(struct {
+		Name string            `json:"name"`
+		Args []json.RawMessage `json:"args"`
+	}{Name: r.Name, Args: args})
+}
+
+// isJSONScalar returns true if s is a JSON number or boolean.
+func isJSONScalar(s string) bool {
+	if s == "true" || s == "false" {
+		return true
+	}
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return false
+	}
+	var n json.Number
+	return json.Unmarshal([]byte(s), &n) == nil
+}
+
+// MarshalYAML implements yaml.Marshaler. A rule without args is a string. Otherwise it is a mapping with a name and
+// args. Args are plain scalars where YAML allows so numbers and booleans are not quoted. Unmarshaling the result
+// returns the same rule.
+func (r Rule) MarshalYAML() (any, error) {
+	if len(r.Args) == 0 {
+		return r.Name, nil
+	}
+
+	args := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, arg := range r.Args {
+		args.Content = append(args.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: arg})
+	}
+
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "name"},
+		{Kind: yaml.ScalarNode, Value: r.Name},
+		{Kind: yaml.ScalarNode, Value: "args"},
+		args,
+	}}, nil
+}
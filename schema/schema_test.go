@@ -0,0 +1,219 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/jackc/ensure/schema"
+	"github.com/jackc/errortree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const personYAML = `
+fields:
+  - name: name
+    rules: [singleline, nilifyempty, require, maxlen=10]
+  - name: age
+    metadata: {section: Personal, group: profile}
+    rules:
+      - int32
+      - name: between
+        args: [0, 150]
+  - name: color
+    rules: [lower, "oneof=red green blue"]
+`
+
+const personJSON = `{"fields": [
+  {"name": "name", "rules": ["singleline", "nilifyempty", "require", "maxlen=10"]},
+  {"name": "age", "metadata": {"section": "Personal", "group": "profile"},
+   "rules": ["int32", {"name": "between", "args": [0, 150]}]},
+  {"name": "color", "rules": ["lower", {"name": "oneof", "args": ["red", "green", "blue"]}]}
+]}`
+
+func TestParse(t *testing.T) {
+	expected := &schema.Schema{Fields: []schema.Field{
+		{Name: "name", Rules: []schema.Rule{
+			{Name: "singleline"}, {Name: "nilifyempty"}, {Name: "require"}, {Name: "maxlen", Args: []string{"10"}},
+		}},
+		{
+			Name:     "age",
+			Rules:    []schema.Rule{{Name: "int32"}, {Name: "between", Args: []string{"0", "150"}}},
+			Metadata: map[string]any{"section": "Personal", "group": "profile"},
+		},
+		{Name: "color", Rules: []schema.Rule{{Name: "lower"}, {Name: "oneof", Args: []string{"red", "green", "blue"}}}},
+	}}
+
+	s, err := schema.ParseYAML([]byte(personYAML))
+	require.NoError(t, err)
+	assert.Equal(t, expected, s)
+
+	s, err = schema.ParseJSON([]byte(personJSON))
+	require.NoError(t, err)
+	assert.Equal(t, expected, s)
+
+	for i, tt := range []struct {
+		parse func([]byte) (*schema.Schema, error)
+		data  string
+	}{
+		{schema.ParseYAML, "fields:\n  - name: a\n    rulez: [require]\n"},
+		{schema.ParseYAML, "fields:\n  - name: a\n    rules:\n      - name: maxlen\n        arg: [1]\n"},
+		{schema.ParseYAML, "fields:\n  - name: a\n    rules:\n      - args: [1]\n"},
+		{schema.ParseYAML, "fields:\n  - name: a\n    rules:\n      - name: oneof\n        args: [[a]]\n"},
+		{schema.ParseYAML, "fields:\n  - name: a\n    rules:\n      - [require]\n"},
+		{schema.ParseJSON, `{"fields": [{"name": "a", "rulez": ["require"]}]}`},
+		{schema.ParseJSON, `{"fields": [{"name": "a", "rules": [{"name": "oneof", "args": [{}]}]}]}`},
+		{schema.ParseJSON, `{"fields": [{"name": "a", "rules": [{"args": [1]}]}]}`},
+		{schema.ParseJSON, `{"fields": [{"name": "a", "rules": [""]}]}`},
+		{schema.ParseJSON, `{"fields": []} {}`},
+	} {
+		_, err := tt.parse([]byte(tt.data))
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestSchemaRecordEnsurer(t *testing.T) {
+	s, err := schema.ParseYAML([]byte(personYAML))
+	require.NoError(t, err)
+
+	re, err := s.RecordEnsurer(nil)
+	require.NoError(t, err)
+
+	record := map[string]any{"name": " Jack ", "age": "42", "color": "RED"}
+	_, err = re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Jack", "age": int32(42), "color": "red"}, record)
+
+	_, err = re.Ensure(map[string]any{"name": "", "age": "200", "color": "pink"})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"name"}), 1)
+	assert.Len(t, etErr.Get([]any{"age"}), 1)
+	assert.Len(t, etErr.Get([]any{"color"}), 1)
+
+	fields := re.Rules()
+	require.Len(t, fields, 3)
+	assert.Nil(t, fields[0].Metadata)
+	assert.Equal(t, map[string]any{"section": "Personal", "group": "profile"}, fields[1].Metadata)
+
+	registry := ensure.NewRegistry()
+	registry.Register("slug", func(args ...string) ensure.Ensurer {
+		return ensure.EnsurerFunc(func(value any) (any, error) {
+			if s, ok := value.(string); ok {
+				return strings.ReplaceAll(strings.ToLower(s), " ", "-"), nil
+			}
+			return value, nil
+		})
+	})
+	s = &schema.Schema{Fields: []schema.Field{{Name: "slug", Rules: []schema.Rule{{Name: "slug"}}}}}
+
+	_, err = s.RecordEnsurer(nil)
+	assert.Error(t, err)
+
+	re, err = s.RecordEnsurer(registry)
+	require.NoError(t, err)
+	record = map[string]any{"slug": "Hello World"}
+	_, err = re.Ensure(record)
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world", record["slug"])
+
+	for i, s := range []*schema.Schema{
+		{Fields: []schema.Field{{Rules: []schema.Rule{{Name: "require"}}}}},
+		{Fields: []schema.Field{{Name: "a"}, {Name: "a"}}},
+		{Fields: []schema.Field{{Name: "a", Rules: []schema.Rule{{Name: "maxlen", Args: []string{"abc"}}}}}},
+	} {
+		_, err := s.RecordEnsurer(nil)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestSchemaOverlay(t *testing.T) {
+	s, err := schema.ParseYAML([]byte(personYAML))
+	require.NoError(t, err)
+	base, err := s.RecordEnsurer(nil)
+	require.NoError(t, err)
+
+	tenant, err := schema.ParseJSON([]byte(`{"fields": [
+		{"name": "name", "rules": ["maxlen=4"]},
+		{"name": "email", "rules": ["singleline", "require"]}
+	]}`))
+	require.NoError(t, err)
+
+	re, err := tenant.Overlay(base, nil)
+	require.NoError(t, err)
+
+	_, err = re.Ensure(map[string]any{"name": "Jack", "email": "jack@example.com"})
+	assert.NoError(t, err)
+
+	_, err = re.Ensure(map[string]any{"name": "Jackson", "age": "200"})
+	var etErr *errortree.Node
+	require.ErrorAs(t, err, &etErr)
+	assert.Len(t, etErr.Get([]any{"name"}), 1)
+	assert.Len(t, etErr.Get([]any{"age"}), 1)
+	assert.Len(t, etErr.Get([]any{"email"}), 1)
+
+	bogus := &schema.Schema{Fields: []schema.Field{{Name: "a", Rules: []schema.Rule{{Name: "bogus"}}}}}
+	_, err = bogus.Overlay(base, nil)
+	assert.Error(t, err)
+}
+
+func TestSchemaMarshal(t *testing.T) {
+	s := &schema.Schema{Fields: []schema.Field{
+		{Name: "name", Rules: []schema.Rule{{Name: "require"}, {Name: "maxlen", Args: []string{"10"}}}},
+		{
+			Name:     "age",
+			Rules:    []schema.Rule{{Name: "between", Args: []string{"-1.50", "1e3"}}},
+			Metadata: map[string]any{"section": "Personal"},
+		},
+		{Name: "answer", Rules: []schema.Rule{
+			{Name: "oneof", Args: []string{"true", "yes", "007", "a b", "#x: y", ""}},
+		}},
+	}}
+
+	buf, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"fields": [
+		{"name": "name", "rules": ["require", {"name": "maxlen", "args": [10]}]},
+		{"name": "age", "rules": [{"name": "between", "args": [-1.50, 1e3]}], "metadata": {"section": "Personal"}},
+		{"name": "answer", "rules": [{"name": "oneof", "args": [true, "yes", "007", "a b", "#x: y", ""]}]}
+	]}`, string(buf))
+
+	fromJSON, err := schema.ParseJSON(buf)
+	require.NoError(t, err)
+	assert.Equal(t, s, fromJSON)
+
+	buf, err = yaml.Marshal(s)
+	require.NoError(t, err)
+	fromYAML, err := schema.ParseYAML(buf)
+	require.NoErrorf(t, err, "%s", buf)
+	assert.Equalf(t, s, fromYAML, "%s", buf)
+	assert.Contains(t, string(buf), "args: [10]")
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "person.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(personYAML), 0o644))
+	jsonPath := filepath.Join(dir, "person.JSON")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(personJSON), 0o644))
+
+	fromYAML, err := schema.ReadFile(yamlPath)
+	require.NoError(t, err)
+	fromJSON, err := schema.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, fromYAML, fromJSON)
+
+	badPath := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(badPath, []byte("fields: []"), 0o644))
+	_, err = schema.ReadFile(badPath)
+	assert.ErrorContains(t, err, badPath)
+
+	_, err = schema.ReadFile(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jackc/ensure"
+)
+
+// StoreEntry is a schema and its version in a Store.
+type StoreEntry struct {
+	// Version identifies the content of Schema such as a revision number, an updated_at timestamp, or a hash. A schema
+	// is only compiled again on reload if its version changed. If Version is empty it is compiled on every reload.
+	Version string
+
+	Schema *Schema
+}
+
+// StoreSource returns the current schemas of a Store by key such as a tenant ID or record type.
+type StoreSource func() (map[string]StoreEntry, error)
+
+// Store compiles the schemas of a StoreSource to record ensurers and caches them by key and version. Reload replaces
+// all of them at once, so a Get during a reload returns either the old or the new ensurer of a key and never a mix of
+// versions. A Store is safe for concurrent use.
+type Store struct {
+	source   StoreSource
+	registry *ensure.Registry
+
+	mu       sync.Mutex
+	compiled atomic.Pointer[map[string]compiledSchema]
+}
+
+// compiledSchema is the record ensurer of a version of a schema.
+type compiledSchema struct {
+	version string
+	re      *ensure.RecordEnsurer
+}
+
+// NewStore returns a Store of the schemas of source compiled with registry. If registry is nil then
+// ensure.DefaultRegistry is used. An error is returned if source fails or a schema does not compile.
+func NewStore(source StoreSource, registry *ensure.Registry) (*Store, error) {
+	s := &Store{source: source, registry: registry}
+	err := s.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the record ensurer and version of key. ok is false if there is no schema for key.
+func (s *Store) Get(key string) (re *ensure.RecordEnsurer, version string, ok bool) {
+	c, ok := (*s.compiled.Load())[key]
+	return c.re, c.version, ok
+}
+
+// Keys returns the keys of the schemas in order.
+func (s *Store) Keys() []string {
+	compiled := *s.compiled.Load()
+	keys := make([]string, 0, len(compiled))
+	for k := range compiled {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Reload gets the schemas from the source and compiles those whose version changed. If the source fails or any schema
+// does not compile then an error is returned and the previous schemas are kept. Otherwise they are all replaced. It is
+// intended to be called by a watcher of the source or on a signal with ReloadOnSignal.
+func (s *Store) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.source()
+	if err != nil {
+		return err
+	}
+
+	var previous map[string]compiledSchema
+	if p := s.compiled.Load(); p != nil {
+		previous = *p
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	compiled := make(map[string]compiledSchema, len(entries))
+	var errs []error
+	for _, key := range keys {
+		entry := entries[key]
+		if c, ok := previous[key]; ok && entry.Version != "" && c.version == entry.Version {
+			compiled[key] = c
+			continue
+		}
+
+		if entry.Schema == nil {
+			errs = append(errs, fmt.Errorf("%s: missing schema", key))
+			continue
+		}
+		re, err := entry.Schema.RecordEnsurer(s.registry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		compiled[key] = compiledSchema{version: entry.Version, re: re}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	s.compiled.Store(&compiled)
+	return nil
+}
+
+// ReloadOnSignal calls Reload each time one of signals is received such as syscall.SIGHUP. Errors are passed to
+// onError, which may be nil. It returns a function that stops reloading. e.g.
+//
+//	stop := store.ReloadOnSignal(func(err error) { log.Printf("reload schemas: %v", err) }, syscall.SIGHUP)
+//	defer stop()
+func (s *Store) ReloadOnSignal(onError func(error), signals ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ch:
+				if err := s.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// DirSource returns a StoreSource of the schema files in dir. The key of a file is its name without the extension.
+// Files with a ".json" extension are parsed as JSON and those with a ".yaml" or ".yml" extension are parsed as YAML.
+// Other files are ignored. The version of a schema is a hash of its file.
+func DirSource(dir string) StoreSource {
+	return func() (map[string]StoreEntry, error) {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make(map[string]StoreEntry)
+		for _, f := range files {
+			ext := filepath.Ext(f.Name())
+			switch strings.ToLower(ext) {
+			case ".json", ".yaml", ".yml":
+			default:
+				continue
+			}
+			if f.IsDir() {
+				continue
+			}
+
+			name := filepath.Join(dir, f.Name())
+			data, err := os.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+
+			s, err := parseFile(name, data)
+			if err != nil {
+				return nil, err
+			}
+
+			sum := sha256.Sum256(data)
+			key := strings.TrimSuffix(f.Name(), ext)
+			if _, ok := entries[key]; ok {
+				return nil, fmt.Errorf("%s: more than one file for %s", name, key)
+			}
+			entries[key] = StoreEntry{Version: hex.EncodeToString(sum[:8]), Schema: s}
+		}
+
+		return entries, nil
+	}
+}
@@ -0,0 +1,127 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/ensure/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	maxLen := func(n string) *schema.Schema {
+		rules := []schema.Rule{{Name: "maxlen", Args: []string{n}}}
+		return &schema.Schema{Fields: []schema.Field{{Name: "name", Rules: rules}}}
+	}
+
+	entries := map[string]schema.StoreEntry{
+		"acme":   {Version: "1", Schema: maxLen("4")},
+		"globex": {Version: "1", Schema: maxLen("10")},
+	}
+	var sourceErr error
+	store, err := schema.NewStore(func() (map[string]schema.StoreEntry, error) {
+		return entries, sourceErr
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "globex"}, store.Keys())
+
+	acme, version, ok := store.Get("acme")
+	require.True(t, ok)
+	assert.Equal(t, "1", version)
+	_, err = acme.Ensure(map[string]any{"name": "Jackson"})
+	assert.Error(t, err)
+	globex, _, _ := store.Get("globex")
+
+	_, _, ok = store.Get("initech")
+	assert.False(t, ok)
+
+	entries = map[string]schema.StoreEntry{
+		"acme":    {Version: "2", Schema: maxLen("10")},
+		"globex":  {Version: "1", Schema: maxLen("1")},
+		"initech": {Schema: maxLen("1")},
+	}
+	require.NoError(t, store.Reload())
+
+	re, version, ok := store.Get("acme")
+	require.True(t, ok)
+	assert.Equal(t, "2", version)
+	_, err = re.Ensure(map[string]any{"name": "Jackson"})
+	assert.NoError(t, err)
+	re, _, _ = store.Get("globex")
+	assert.Same(t, globex, re, "unchanged version is not compiled again")
+	_, _, ok = store.Get("initech")
+	assert.True(t, ok)
+
+	entries = map[string]schema.StoreEntry{
+		"acme": {Version: "3", Schema: maxLen("abc")},
+	}
+	assert.Error(t, store.Reload())
+	_, version, _ = store.Get("acme")
+	assert.Equal(t, "2", version)
+	assert.Len(t, store.Keys(), 3)
+
+	sourceErr = errors.New("database down")
+	assert.ErrorIs(t, store.Reload(), sourceErr)
+
+	_, err = schema.NewStore(func() (map[string]schema.StoreEntry, error) { return nil, sourceErr }, nil)
+	assert.ErrorIs(t, err, sourceErr)
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "person.yaml"), []byte(personYAML), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Schemas"), 0o644))
+
+	store, err := schema.NewStore(schema.DirSource(dir), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"person"}, store.Keys())
+	_, version, _ := store.Get("person")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "person.yaml"), []byte(personYAML+"  - name: email\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "order.JSON"), []byte(personJSON), 0o644))
+	require.NoError(t, store.Reload())
+	assert.Equal(t, []string{"order", "person"}, store.Keys())
+	_, newVersion, _ := store.Get("person")
+	assert.NotEqual(t, version, newVersion)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "order.yml"), []byte(personYAML), 0o644))
+	assert.Error(t, store.Reload())
+
+	_, err = schema.NewStore(schema.DirSource(filepath.Join(dir, "missing")), nil)
+	assert.Error(t, err)
+}
+
+func TestStoreReloadOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not supported on Windows")
+	}
+
+	loads := make(chan struct{}, 10)
+	store, err := schema.NewStore(func() (map[string]schema.StoreEntry, error) {
+		loads <- struct{}{}
+		return nil, nil
+	}, nil)
+	require.NoError(t, err)
+	<-loads
+
+	stop := store.ReloadOnSignal(nil, syscall.SIGHUP)
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+	select {
+	case <-loads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("schemas were not reloaded")
+	}
+
+	stop()
+	stop()
+}
@@ -0,0 +1,81 @@
+package ensure
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// SliceEach returns a Ensurer that checks each element of value, which must be a slice of any type, with constraints.
+// Unlike Slice it does not convert value or its elements; the values returned by constraints are discarded and value is
+// returned unmodified. Errors are reported for each element like Slice. If value is nil then nil is returned.
+func SliceEach(constraints ...Ensurer) Ensurer {
+	ensurer := EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		slice := reflect.ValueOf(value)
+		if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+			return nil, NewError("not_a_slice", "cannot convert to slice", nil)
+		}
+
+		var elErrs sliceElementErrors
+		for i := 0; i < slice.Len(); i++ {
+			_, err := convertSlice(warningContext(ctx, i), slice.Index(i).Interface(), constraints)
+			if err != nil {
+				elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
+			}
+		}
+
+		if elErrs != nil {
+			return nil, elErrs
+		}
+
+		return value, nil
+	})
+
+	return describe("sliceeach", map[string]any{"ensurers": constraints}, ensurer)
+}
+
+// ordered is the set of types that support the < operator.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 |
+		~float64 | ~string
+}
+
+// Sorted returns a Ensurer that fails unless value, which must be a []T or a []any of T, is in ascending order.
+// Adjacent equal elements are allowed. The first element that is less than its predecessor is reported as an error on
+// its index. If value is nil then nil is returned.
+func Sorted[T ordered]() Ensurer {
+	return describe("sorted", nil, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		var ts []T
+		switch value := value.(type) {
+		case []T:
+			ts = value
+		case []any:
+			ts = make([]T, len(value))
+			for i, element := range value {
+				t, ok := element.(T)
+				if !ok {
+					return nil, sliceElementErrors{{Index: i, Err: NewError("invalid_type", fmt.Sprintf("not a %T", t), map[string]any{"type": fmt.Sprintf("%T", t)})}}
+				}
+				ts[i] = t
+			}
+		default:
+			return nil, NewError("not_a_slice", "cannot convert to slice", nil)
+		}
+
+		for i := 1; i < len(ts); i++ {
+			if ts[i] < ts[i-1] {
+				return nil, sliceElementErrors{{Index: i, Err: NewError("not_sorted", fmt.Sprintf("must not be less than element %d", i-1), map[string]any{"index": i - 1})}}
+			}
+		}
+
+		return value, nil
+	}))
+}
@@ -0,0 +1,86 @@
+package ensure_test
+
+import (
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceEach(t *testing.T) {
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		success bool
+	}{
+		{ensure.SliceEach(ensure.GreaterThan(0)), []int32{1, 2, 3}, true},
+		{ensure.SliceEach(ensure.GreaterThan(0)), []int32{1, 0, 3}, false},
+		{ensure.SliceEach(ensure.MaxLen(3)), []string{"abc", "de"}, true},
+		{ensure.SliceEach(ensure.MaxLen(3)), []string{"abcd"}, false},
+		{ensure.SliceEach(ensure.Lower()), []string{"ABC"}, true},
+		{ensure.SliceEach(ensure.NotNil()), []any{1, nil}, false},
+		{ensure.SliceEach(ensure.NotNil()), "abc", false},
+		{ensure.SliceEach(ensure.NotNil()), nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			assert.Nilf(t, value, "%d", i)
+			assert.Errorf(t, err, "%d", i)
+		}
+	}
+}
+
+func TestSorted(t *testing.T) {
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		success bool
+	}{
+		{ensure.Sorted[int32](), []int32{1, 2, 2, 3}, true},
+		{ensure.Sorted[int32](), []int32{1, 3, 2}, false},
+		{ensure.Sorted[string](), []string{"a", "b", "c"}, true},
+		{ensure.Sorted[string](), []any{"b", "a"}, false},
+		{ensure.Sorted[string](), []any{"a", 1}, false},
+		{ensure.Sorted[float64](), []float64{}, true},
+		{ensure.Sorted[int](), "abc", false},
+		{ensure.Sorted[int](), nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			assert.Nilf(t, value, "%d", i)
+			assert.Errorf(t, err, "%d", i)
+		}
+	}
+}
+
+func TestSliceEachRecordErrorPaths(t *testing.T) {
+	record := ensure.GetterSetterMap{"scores": []int32{90, -1, 80, 200}}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("scores", ensure.SliceEach(ensure.Between(0, 100)), ensure.Sorted[int32]())
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	fieldErrs := recordErrs.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "scores[1]: must be between 0 and 100", fieldErrs[0].Error())
+	assert.Equal(t, "scores[3]: must be between 0 and 100", fieldErrs[1].Error())
+
+	record = ensure.GetterSetterMap{"scores": []int32{90, 10}}
+	errs = ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("scores", ensure.SliceEach(ensure.Between(0, 100)), ensure.Sorted[int32]())
+	})
+	require.ErrorAs(t, errs, &recordErrs)
+	assert.Equal(t, "scores[1]: must not be less than element 0", recordErrs.FieldErrors()[0].Error())
+}
@@ -0,0 +1,66 @@
+package ensure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Translator returns the message for err in locale. ok is false if it has no message for err.
+type Translator interface {
+	Translate(locale string, err *Error) (message string, ok bool)
+}
+
+// Catalog is a Translator of message templates keyed by locale and then by error code. e.g.
+//
+//	ensure.Catalog{
+//		"es": {"required": "es obligatorio", "too_long": "debe tener como máximo {max} caracteres"},
+//	}
+//
+// "{name}" in a template is replaced with the param name of the error. If there is no message for a locale such as
+// "es-MX" then the language "es" is tried.
+type Catalog map[string]map[string]string
+
+func (c Catalog) Translate(locale string, err *Error) (string, bool) {
+	template, ok := c[locale][err.Code]
+	if !ok {
+		if i := strings.IndexAny(locale, "-_"); i >= 0 {
+			template, ok = c[locale[:i]][err.Code]
+		}
+	}
+	if !ok {
+		return "", false
+	}
+
+	if len(err.Params) == 0 {
+		return template, true
+	}
+
+	oldnew := make([]string, 0, len(err.Params)*2)
+	for name, value := range err.Params {
+		oldnew = append(oldnew, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(oldnew...).Replace(template), true
+}
+
+// Translate returns the message for err in locale using t. If err is not an *Error or t has no message for it then
+// err.Error() is returned.
+func Translate(t Translator, locale string, err error) string {
+	var ensureErr *Error
+	if errors.As(err, &ensureErr) {
+		if message, ok := t.Translate(locale, ensureErr); ok {
+			return message
+		}
+	}
+	return err.Error()
+}
+
+// Translate returns the messages of e in locale using t grouped like ByField.
+func (e *RecordErrors) Translate(t Translator, locale string) map[string][]string {
+	messages := make(map[string][]string)
+	for _, fe := range e.fieldErrors {
+		field := fe.Path.String()
+		messages[field] = append(messages[field], Translate(t, locale, fe.Err))
+	}
+	return messages
+}
@@ -0,0 +1,50 @@
+package ensure_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := ensure.Catalog{
+		"es":    {"required": "es obligatorio", "too_long": "como máximo {max} caracteres"},
+		"es-MX": {"required": "es requerido"},
+	}
+
+	tests := []struct {
+		locale   string
+		err      error
+		expected string
+	}{
+		{"es", ensure.NewError("required", "cannot be nil or empty", nil), "es obligatorio"},
+		{"es-MX", ensure.NewError("required", "cannot be nil or empty", nil), "es requerido"},
+		{"es-MX", ensure.NewError("too_long", "too long", map[string]any{"max": 10}), "como máximo 10 caracteres"},
+		{"es_AR", ensure.NewError("required", "cannot be nil or empty", nil), "es obligatorio"},
+		{"fr", ensure.NewError("required", "cannot be nil or empty", nil), "cannot be nil or empty"},
+		{"es", ensure.NewError("too_short", "too short", nil), "too short"},
+		{"es", errors.New("boom"), "boom"},
+	}
+
+	for i, tt := range tests {
+		assert.Equalf(t, tt.expected, ensure.Translate(catalog, tt.locale, tt.err), "%d", i)
+	}
+}
+
+func TestRecordErrorsTranslate(t *testing.T) {
+	catalog := ensure.Catalog{"es": {"required": "es obligatorio", "too_long": "máximo {max}"}}
+
+	err := ensure.Record(ensure.GetterSetterMap{"name": "", "code": "abcd"}, func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", ensure.NilifyEmpty(), ensure.Require())
+		r.Ensure("code", ensure.MaxLen(3))
+	})
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	assert.Equal(t, map[string][]string{
+		"name": {"es obligatorio"},
+		"code": {"máximo 3"},
+	}, recordErrs.Translate(catalog, "es"))
+}
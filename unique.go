@@ -0,0 +1,97 @@
+package ensure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// UniqueElements returns a Ensurer that fails if value, which must be a slice, contains duplicate elements. Each
+// duplicate is reported as an error on its index, like the errors returned by Slice, so with RecordWithErrors.Ensure
+// it is added with a path such as "tags[3]". Elements must be comparable. If value is nil then nil is returned.
+func UniqueElements() Ensurer {
+	return describe("uniqueelements", nil, UniqueElementsBy(func(element any) any { return element }))
+}
+
+// UniqueElementsBy returns a Ensurer like UniqueElements that considers two elements duplicates if key returns the same
+// value for both. e.g. a key func that returns the lower cased address of each email recipient. The values returned by
+// key must be comparable.
+func UniqueElementsBy(key func(element any) any) Ensurer {
+	return describe("uniqueelementsby", nil, EnsurerFunc(func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		slice := reflect.ValueOf(value)
+		if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+			return nil, NewError("not_a_slice", "cannot convert to slice", nil)
+		}
+
+		seen := make(map[any]int, slice.Len())
+		var elErrs sliceElementErrors
+		for i := 0; i < slice.Len(); i++ {
+			k := key(slice.Index(i).Interface())
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				elErrs = append(elErrs, sliceElementError{Index: i, Err: NewError("invalid_type", fmt.Sprintf("%T is not comparable", k), nil)})
+				continue
+			}
+
+			if first, ok := seen[k]; ok {
+				elErrs = append(elErrs, sliceElementError{Index: i, Err: NewError("duplicate", fmt.Sprintf("duplicate of element %d", first), map[string]any{"index": first})})
+				continue
+			}
+			seen[k] = i
+		}
+
+		if elErrs != nil {
+			return nil, elErrs
+		}
+
+		return value, nil
+	}))
+}
+
+// Unique returns a Ensurer that fails with the code "taken" unless check returns true for value. It is intended for
+// rules such as "email is already taken" that are enforced by a database. check is called with the context of the
+// record when used with RecordContext or RecordEnsurer.EnsureContext. If check returns an error, it is returned. Unique
+// should usually be the last ensurer for a field and be in a later phase added with RecordEnsurer.Then so it only runs
+// for otherwise valid records. Unique is an External check so it is limited by the Budget of the record. If value is
+// nil then nil is returned without calling check.
+func Unique(check func(ctx context.Context, value any) (bool, error)) Ensurer {
+	return describe("unique", nil, External(EnsurerContextFunc(func(ctx context.Context, value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+
+		unique, err := check(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if !unique {
+			return nil, NewError("taken", "is already taken", nil)
+		}
+
+		return value, nil
+	})))
+}
+
+// SQLQueryer is implemented by *sql.DB, *sql.Conn, and *sql.Tx.
+type SQLQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// SQLUnique returns a check for Unique that runs query with value as its only argument. query must return a single
+// boolean that is true if value is unique. e.g. with PostgreSQL:
+//
+//	ensure.Unique(ensure.SQLUnique(db, "select not exists(select 1 from users where email = $1)"))
+func SQLUnique(db SQLQueryer, query string) func(ctx context.Context, value any) (bool, error) {
+	return func(ctx context.Context, value any) (bool, error) {
+		var unique bool
+		err := db.QueryRowContext(ctx, query, value).Scan(&unique)
+		if err != nil {
+			return false, fmt.Errorf("failed to check uniqueness: %w", err)
+		}
+		return unique, nil
+	}
+}
@@ -0,0 +1,174 @@
+package ensure_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueElements(t *testing.T) {
+	tests := []struct {
+		ensurer ensure.Ensurer
+		value   any
+		success bool
+	}{
+		{ensure.UniqueElements(), []string{"a", "b", "c"}, true},
+		{ensure.UniqueElements(), []string{"a", "b", "a"}, false},
+		{ensure.UniqueElements(), []any{1, "1", int64(1)}, true},
+		{ensure.UniqueElements(), []any{nil, nil}, false},
+		{ensure.UniqueElements(), []any{[]int{1}}, false},
+		{ensure.UniqueElements(), []int{}, true},
+		{ensure.UniqueElementsBy(func(e any) any { return strings.ToLower(e.(string)) }), []string{"A@x.com", "a@X.com"}, false},
+		{ensure.UniqueElementsBy(func(e any) any { return e.(map[string]any)["id"] }), []any{map[string]any{"id": 1}, map[string]any{"id": 2}}, true},
+		{ensure.UniqueElements(), "abc", false},
+		{ensure.UniqueElements(), nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.ensurer.Ensure(tt.value)
+		if tt.success {
+			assert.Equalf(t, tt.value, value, "%d", i)
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			assert.Nilf(t, value, "%d", i)
+			assert.Errorf(t, err, "%d", i)
+		}
+	}
+}
+
+func TestUniqueElementsRecordErrorPaths(t *testing.T) {
+	record := ensure.GetterSetterMap{"tags": []any{"go", "sql", "go", "web", "sql"}}
+	errs := ensure.Record(record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("tags", ensure.Slice[string](ensure.String()), ensure.UniqueElements())
+	})
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, errs, &recordErrs)
+	fieldErrs := recordErrs.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "tags[2]: duplicate of element 0", fieldErrs[0].Error())
+	assert.Equal(t, "tags[4]: duplicate of element 1", fieldErrs[1].Error())
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[any]bool{"jack@example.com": true}
+	var calls int
+	email := ensure.Unique(func(ctx context.Context, value any) (bool, error) {
+		calls++
+		if value == "error@example.com" {
+			return false, errors.New("connection refused")
+		}
+		return !taken[value], nil
+	})
+
+	tests := []struct {
+		value    any
+		expected any
+		code     string
+	}{
+		{"jill@example.com", "jill@example.com", ""},
+		{"jack@example.com", nil, "taken"},
+		{"error@example.com", nil, ""},
+		{nil, nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := email.Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		if tt.code != "" {
+			var ensureErr *ensure.Error
+			require.ErrorAsf(t, err, &ensureErr, "%d", i)
+			assert.Equalf(t, tt.code, ensureErr.Code, "%d", i)
+		}
+	}
+	assert.Equal(t, 3, calls)
+	_, err := email.Ensure("error@example.com")
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestSQLUnique(t *testing.T) {
+	db := sql.OpenDB(fakeUniqueConnector{taken: "jack@example.com"})
+	defer db.Close()
+
+	email := ensure.Unique(ensure.SQLUnique(db, "select not exists(select 1 from users where email = $1)"))
+
+	record := ensure.GetterSetterMap{"email": "jill@example.com"}
+	err := ensure.RecordContext(context.Background(), record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", email)
+	})
+	require.NoError(t, err)
+
+	record = ensure.GetterSetterMap{"email": "jack@example.com"}
+	err = ensure.RecordContext(context.Background(), record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", email)
+	})
+	require.Error(t, err)
+
+	var recordErrs *ensure.RecordErrors
+	require.ErrorAs(t, err, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	var ensureErr *ensure.Error
+	require.ErrorAs(t, recordErrs.FieldErrors()[0].Err, &ensureErr)
+	assert.Equal(t, "taken", ensureErr.Code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	record = ensure.GetterSetterMap{"email": "jill@example.com"}
+	err = ensure.RecordContext(ctx, record, func(r *ensure.RecordWithErrors) {
+		r.Ensure("email", email)
+	})
+	require.ErrorAs(t, err, &recordErrs)
+	require.Len(t, recordErrs.FieldErrors(), 1)
+	assert.ErrorIs(t, recordErrs.FieldErrors()[0].Err, context.Canceled)
+}
+
+// fakeUniqueConnector is a database/sql driver whose queries return whether their only argument differs from taken.
+type fakeUniqueConnector struct {
+	taken string
+}
+
+func (c fakeUniqueConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeUniqueConn(c), nil
+}
+func (c fakeUniqueConnector) Driver() driver.Driver { return nil }
+
+type fakeUniqueConn fakeUniqueConnector
+
+func (c fakeUniqueConn) Prepare(query string) (driver.Stmt, error) { return fakeUniqueStmt(c), nil }
+func (c fakeUniqueConn) Close() error                              { return nil }
+func (c fakeUniqueConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeUniqueStmt fakeUniqueConnector
+
+func (s fakeUniqueStmt) Close() error  { return nil }
+func (s fakeUniqueStmt) NumInput() int { return 1 }
+func (s fakeUniqueStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s fakeUniqueStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeUniqueRows{unique: args[0] != s.taken}, nil
+}
+
+type fakeUniqueRows struct {
+	unique bool
+	done   bool
+}
+
+func (r *fakeUniqueRows) Columns() []string { return []string{"unique"} }
+func (r *fakeUniqueRows) Close() error      { return nil }
+func (r *fakeUniqueRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.unique
+	return nil
+}
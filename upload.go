@@ -0,0 +1,46 @@
+package ensure
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+)
+
+type readerAtSeeker interface {
+	io.ReaderAt
+	io.Seeker
+}
+
+// openUpload returns a reader for the contents of an uploaded file. value must be a []byte, *multipart.FileHeader, or
+// a value that implements both io.ReaderAt and io.Seeker such as *os.File. The returned reader does not change the read
+// position of value. closeUpload must be called when the reader is no longer needed.
+func openUpload(value any) (r *io.SectionReader, closeUpload func() error, err error) {
+	noop := func() error { return nil }
+
+	switch value := value.(type) {
+	case []byte:
+		return io.NewSectionReader(bytes.NewReader(value), 0, int64(len(value))), noop, nil
+	case *multipart.FileHeader:
+		f, err := value.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		return io.NewSectionReader(f, 0, value.Size), f.Close, nil
+	case readerAtSeeker:
+		pos, err := value.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, err
+		}
+		size, err := value.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, err = value.Seek(pos, io.SeekStart)
+		if err != nil {
+			return nil, nil, err
+		}
+		return io.NewSectionReader(value, 0, size), noop, nil
+	}
+
+	return nil, nil, NewError("not_a_file", "not a file", nil)
+}
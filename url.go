@@ -0,0 +1,91 @@
+package ensure
+
+import (
+	"net/url"
+	"strings"
+)
+
+// URLEnsurer is a Ensurer that converts value to an absolute URL. It is returned by URL.
+type URLEnsurer struct {
+	schemes []string
+	asURL   bool
+}
+
+// URL returns a *URLEnsurer that converts value to an absolute URL. value must be a string or *url.URL. If schemes are
+// given then only URLs with those schemes are allowed. Otherwise, only http and https are allowed. Space is trimmed
+// from both sides of a string and the scheme and host are lowercased. The normalized URL is returned as a string unless
+// AsURL is used. If value is nil or a blank string nil is returned.
+func URL(schemes ...string) *URLEnsurer {
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https"}
+	}
+
+	normalizedSchemes := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		normalizedSchemes[i] = strings.ToLower(scheme)
+	}
+
+	return &URLEnsurer{schemes: normalizedSchemes}
+}
+
+// AsURL returns a copy of ue that returns a *url.URL instead of a string.
+func (ue *URLEnsurer) AsURL() *URLEnsurer {
+	newUE := *ue
+	newUE.asURL = true
+	return &newUE
+}
+
+func (ue *URLEnsurer) Describe() Rule {
+	return Rule{Kind: "url", Params: map[string]any{"schemes": ue.schemes}}
+}
+
+func (ue *URLEnsurer) Ensure(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var u *url.URL
+	switch value := value.(type) {
+	case string:
+		var err error
+		u, err = url.Parse(value)
+		if err != nil {
+			return nil, NewError("invalid_url", "not a valid URL", nil)
+		}
+	case *url.URL:
+		newURL := *value
+		u = &newURL
+	default:
+		return nil, NewError("invalid_url", "not a valid URL", nil)
+	}
+
+	if !u.IsAbs() {
+		return nil, NewError("invalid_url", "not an absolute URL", nil)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return nil, NewError("invalid_url", "not an absolute URL", nil)
+	}
+
+	allowed := false
+	for _, scheme := range ue.schemes {
+		if u.Scheme == scheme {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, NewError("url_scheme_not_allowed", "URL scheme not allowed", map[string]any{"schemes": ue.schemes})
+	}
+
+	if ue.asURL {
+		return u, nil
+	}
+
+	return u.String(), nil
+}
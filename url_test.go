@@ -0,0 +1,45 @@
+package ensure_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		value    any
+		schemes  []string
+		expected any
+		success  bool
+	}{
+		{"https://example.com/foo?bar=baz", nil, "https://example.com/foo?bar=baz", true},
+		{"  HTTP://Example.COM/Foo \n", nil, "http://example.com/Foo", true},
+		{&url.URL{Scheme: "https", Host: "example.com"}, nil, "https://example.com", true},
+		{"/relative/path", nil, nil, false},
+		{"example.com", nil, nil, false},
+		{"http:///foo", nil, nil, false},
+		{"ftp://example.com/file", nil, nil, false},
+		{"ftp://example.com/file", []string{"ftp"}, "ftp://example.com/file", true},
+		{"mailto:foo@example.com", []string{"MAILTO"}, "mailto:foo@example.com", true},
+		{"javascript:alert(1)", nil, nil, false},
+		{"http://[::1", nil, nil, false},
+		{42, nil, nil, false},
+		{nil, nil, nil, true},
+		{"  ", nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := ensure.URL(tt.schemes...).Ensure(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d: %v", i, err)
+	}
+}
+
+func TestURLAsURL(t *testing.T) {
+	value, err := ensure.URL().AsURL().Ensure(" https://Example.com/foo ")
+	assert.NoError(t, err)
+	assert.Equal(t, &url.URL{Scheme: "https", Host: "example.com", Path: "/foo"}, value)
+}
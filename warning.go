@@ -0,0 +1,90 @@
+package ensure
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotChecked matches the errors of checks that were skipped instead of failing, such as an External ensurer over
+// the Budget of its record. When an ensurer returns an error with the code "not_checked" the field does not fail.
+// Instead its value is passed unchanged to the next ensurer and the error is added as a warning. Use CollectWarnings
+// to get the warnings of a record.
+var ErrNotChecked = NewError("not_checked", "was not checked", nil)
+
+// Warnings collects the warnings of the records ensured with a context returned by CollectWarnings. It is safe for
+// concurrent use.
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []*FieldError
+}
+
+// List returns the warnings in the order they were added. Path is relative to the outermost record ensured with the
+// context.
+func (w *Warnings) List() []*FieldError {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]*FieldError(nil), w.warnings...)
+}
+
+func (w *Warnings) add(fe *FieldError) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, fe)
+}
+
+// CollectWarnings returns a copy of ctx that collects the warnings of the records ensured with it. Warnings are
+// discarded if the context of a record does not collect them. e.g.
+//
+//	ctx, warnings := ensure.CollectWarnings(r.Context())
+//	_, err := PersonEnsurer.EnsureContext(ctx, record)
+//	for _, w := range warnings.List() {
+//		log.Printf("unverified %v", w)
+//	}
+func CollectWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, &warningScope{warnings: w}), w
+}
+
+type warningsKey struct{}
+
+// warningScope is the Warnings of a context and the path warnings added with the context are added at.
+type warningScope struct {
+	warnings *Warnings
+	path     Path
+}
+
+// warningContext returns ctx with segments appended to the path of its warnings. ctx is returned unmodified if it does
+// not collect warnings.
+func warningContext(ctx context.Context, segments ...any) context.Context {
+	scope, ok := ctx.Value(warningsKey{}).(*warningScope)
+	if !ok {
+		return ctx
+	}
+	scope = &warningScope{warnings: scope.warnings, path: scope.path.append(segments...)}
+	return context.WithValue(ctx, warningsKey{}, scope)
+}
+
+// addWarning adds err as a warning at the path of ctx if ctx collects warnings.
+func addWarning(ctx context.Context, err error) {
+	if scope, ok := ctx.Value(warningsKey{}).(*warningScope); ok {
+		scope.warnings.add(&FieldError{Path: scope.path, Err: err})
+	}
+}
+
+// ensureOrWarn calls e like ensureContext. If e returns an error matching ErrNotChecked it is added as a warning and
+// value is returned unchanged.
+func ensureOrWarn(ctx context.Context, e Ensurer, value any) (any, error) {
+	result, err := ensureContext(ctx, e, value)
+	if err != nil && errors.Is(err, ErrNotChecked) {
+		addWarning(ctx, err)
+		return value, nil
+	}
+	return result, err
+}
+
+// Warn adds err as a warning for field. Unlike Add, it does not fail the record. The warning is discarded unless the
+// record is ensured with a context returned by CollectWarnings.
+func (r *RecordWithErrors) Warn(field string, err error) {
+	addWarning(warningContext(r.Context(), field), err)
+}
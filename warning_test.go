@@ -0,0 +1,49 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/ensure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectWarnings(t *testing.T) {
+	notChecked := ensure.EnsurerFunc(func(value any) (any, error) {
+		return "ignored", ensure.NewError("not_checked", "was not checked", nil)
+	})
+
+	re := ensure.NewRecordEnsurer(func(r *ensure.RecordWithErrors) {
+		r.Ensure("name", notChecked, ensure.SingleLineString())
+		r.Ensure("items", ensure.Slice[map[string]any](ensure.Nested(func(r *ensure.RecordWithErrors) {
+			r.Ensure("sku", ensure.All(ensure.String(), notChecked))
+		})))
+		r.Ensure("tags", ensure.Map[string, string](ensure.String(), notChecked))
+		r.Warn("age", errors.New("unverified"))
+	})
+
+	record := map[string]any{
+		"name":  " Jack ",
+		"items": []any{map[string]any{"sku": "a"}, map[string]any{"sku": "b"}},
+		"tags":  map[string]any{"color": "red"},
+	}
+	ctx, warnings := ensure.CollectWarnings(context.Background())
+	_, err := re.EnsureContext(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, "Jack", record["name"])
+	assert.Equal(t, []map[string]any{{"sku": "a"}, {"sku": "b"}}, record["items"])
+	assert.Equal(t, map[string]string{"color": "red"}, record["tags"])
+
+	var paths []string
+	for _, w := range warnings.List() {
+		paths = append(paths, w.Path.String())
+	}
+	assert.Equal(t, []string{"name", "items[0].sku", "items[1].sku", "tags.color", "age"}, paths)
+	assert.ErrorIs(t, warnings.List()[0].Err, ensure.ErrNotChecked)
+	assert.EqualError(t, warnings.List()[4], "age: unverified")
+
+	_, err = re.Ensure(map[string]any{"name": "Jill"})
+	assert.NoError(t, err)
+}